@@ -1,61 +1,233 @@
 package cmd
 
 import (
-	"go/ast"
-	"io"
+	"path/filepath"
+	"strings"
 
-	"golang.org/x/tools/go/packages"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
 )
 
-const (
-	header = `// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
-// THIS FILE SHOULD NOT BE EDITED BY HAND
-package %s
-`
-	compositeClzTemplateBegin = `type %s struct {
-	%s
-	%s
-`
-	compositeClzTemplateEnd = `
+// CommandOptions is an entry of YAML configuration
+type CommandOptions = mockcompose.Options
+
+// must be public for it to be used in loading YAML configuration
+type Config struct {
+	Defaults    Defaults         `yaml:"defaults"`
+	Mockcompose []CommandOptions `yaml:"mockcompose,flow"`
+
+	keys []map[string]bool // options that are set by each entry
 }
 
-`
-	mockClzTemplate = `type %s struct {
-	%s
+// Defaults are options that configuration entries take unless they set them,
+// options that are not set take the defaults of the nearest ancestor configuration
+type Defaults struct {
+	TestOnly *bool   `yaml:"testOnly"`
+	MockPkg  *string `yaml:"mockPkg"`
+	Output   *string `yaml:"output"`   // directory of generated files
+	FileName *string `yaml:"fileName"` // template of generated file names
+	Backend  *string `yaml:"backend"`
+	Expecter *bool   `yaml:"expecter"`
+	Template *string `yaml:"template"` // relative to the directory of the configuration
 }
 
-`
-)
+func (cfg *Config) UnmarshalYAML(value *yaml.Node) error {
+	type config Config
+	if err := value.Decode((*config)(cfg)); err != nil {
+		return err
+	}
 
-// must be public for it to be used in loading YAML configuration
-type CommandOptions struct {
-	MockName string `yaml:"name"`
-	MockPkg  string `yaml:"mockPkg"`
-	ClzName  string `yaml:"className"`
-	IntfName string `yaml:"interfaceName"`
-	SrcPkg   string `yaml:"sourcePkg"`
-	TestOnly bool   `yaml:"testOnly"`
+	// record options that are set by entries to tell them from zero values
+	var entries struct {
+		Mockcompose []map[string]interface{} `yaml:"mockcompose"`
+	}
+	if err := value.Decode(&entries); err != nil {
+		return err
+	}
 
-	// For example content: "functionThatUsesMultileGlobalFunctions,fmt=fmtMock:json=jsonMock",
-	// it means to use "fmtMock" class as package "fmt", "jsonMock" class as package "json" in the cloned function
-	//
-	// For example content: "functionThatUsesMultileGlobalFunctions,this:.:fmt:json",
-	// it means to mock peer callee methods (this as psudo package name), auto generated callee packages for "." package, "fmt" amd "json" package
+	cfg.keys = make([]map[string]bool, len(entries.Mockcompose))
+	for i, entry := range entries.Mockcompose {
+		cfg.keys[i] = make(map[string]bool)
+		for key := range entry {
+			cfg.keys[i][key] = true
+		}
+	}
+	return nil
+}
 
-	MethodsToClone []string `yaml:"real,flow"`
+// inherit takes defaults of parent that are not set
+func (d *Defaults) inherit(parent Defaults) {
+	if d.TestOnly == nil {
+		d.TestOnly = parent.TestOnly
+	}
+	if d.MockPkg == nil {
+		d.MockPkg = parent.MockPkg
+	}
+	if d.Output == nil {
+		d.Output = parent.Output
+	}
+	if d.FileName == nil {
+		d.FileName = parent.FileName
+	}
+	if d.Backend == nil {
+		d.Backend = parent.Backend
+	}
+	if d.Expecter == nil {
+		d.Expecter = parent.Expecter
+	}
+	if d.Template == nil {
+		d.Template = parent.Template
+	}
+}
 
-	MethodsToMock []string `yaml:"mock,flow"`
+// resolve makes paths of defaults that refer to files absolute, configuration
+// is in dir
+func (d *Defaults) resolve(dir string) {
+	if d.Template != nil && *d.Template != "" && !filepath.IsAbs(*d.Template) {
+		template := filepath.Join(dir, *d.Template)
+		d.Template = &template
+	}
 }
 
-// must be public for it to be used in loading YAML configuration
-type Config struct {
-	Mockcompose []CommandOptions `yaml:"mockcompose,flow"`
+// applyDefaults sets defaults to options that are not set by entries
+func (cfg *Config) applyDefaults() {
+	d := cfg.Defaults
+
+	for i := range cfg.Mockcompose {
+		options := &cfg.Mockcompose[i]
+
+		isSet := func(key string) bool {
+			return i < len(cfg.keys) && cfg.keys[i][key]
+		}
+
+		if !isSet("testOnly") {
+			// testOnly defaults to true like -testonly command line option
+			options.TestOnly = d.TestOnly == nil || *d.TestOnly
+		}
+		if d.MockPkg != nil && !isSet("mockPkg") {
+			options.MockPkg = *d.MockPkg
+		}
+		if d.Output != nil && !isSet("output") {
+			options.Output = *d.Output
+			if options.Output != "" && !strings.HasSuffix(options.Output, "/") &&
+				!strings.HasSuffix(options.Output, string(filepath.Separator)) {
+				// default output is always a directory
+				options.Output += "/"
+			}
+		}
+		if d.FileName != nil && !isSet("fileName") {
+			options.FileName = *d.FileName
+		}
+		if d.Backend != nil && !isSet("backend") {
+			options.Backend = *d.Backend
+		}
+		if d.Expecter != nil && !isSet("expecter") {
+			options.Expecter = *d.Expecter
+		}
+		if d.Template != nil && !isSet("template") {
+			options.Template = *d.Template
+		}
+	}
+}
+
+// flagKeys maps command line options that apply on top of YAML configuration
+// to their keys in configuration entries
+var flagKeys = map[string]string{
+	"testonly": "testOnly",
+	"pkg":      "mockPkg",
+	"o":        "output",
+	"backend":  "backend",
+	"expecter": "expecter",
+	"spy":      "spy",
+	"template": "template",
+}
+
+// selection selects configuration entries to execute and applies command line
+// options on top of them, command line options take precedence over options of
+// entries, which take precedence over defaults of configuration
+type selection struct {
+	flags   CommandOptions  // values of command line options
+	set     map[string]bool // command line options that are set
+	only    []string        // names of entries to execute, all if empty
+	matched map[string]bool // names in only that match entries
+}
+
+func newSelection(flags CommandOptions, set map[string]bool, only []string) *selection {
+	return &selection{
+		flags:   flags,
+		set:     set,
+		only:    only,
+		matched: make(map[string]bool),
+	}
+}
+
+// entries returns entries of cfg selected by name with command line options applied
+func (s *selection) entries(cfg *Config) []CommandOptions {
+	entries := []CommandOptions{}
+	for _, options := range cfg.Mockcompose {
+		if len(s.only) > 0 {
+			if !contains(s.only, options.MockName) {
+				continue
+			}
+			s.matched[options.MockName] = true
+		}
+
+		s.apply(&options)
+		entries = append(entries, options)
+	}
+	return entries
+}
+
+// apply sets command line options that are set to options
+func (s *selection) apply(options *CommandOptions) {
+	for name := range s.set {
+		switch flagKeys[name] {
+		case "testOnly":
+			options.TestOnly = s.flags.TestOnly
+		case "mockPkg":
+			options.MockPkg = s.flags.MockPkg
+		case "output":
+			options.Output = s.flags.Output
+		case "backend":
+			options.Backend = s.flags.Backend
+		case "expecter":
+			options.Expecter = s.flags.Expecter
+		case "spy":
+			options.Spy = s.flags.Spy
+		case "template":
+			options.Template = s.flags.Template
+		}
+	}
+}
+
+// unmatched returns names in only that match no entry, in order
+func (s *selection) unmatched() []string {
+	names := []string{}
+	for _, name := range s.only {
+		if !s.matched[name] && !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
-type parsedFileGenerator interface {
-	generate(writer io.Writer, file *ast.File) error
+// setKeys returns keys of configuration entry that are set by command line
+func (s *selection) setKeys() map[string]bool {
+	keys := make(map[string]bool)
+	for name := range s.set {
+		if key, ok := flagKeys[name]; ok {
+			keys[key] = true
+		}
+	}
+	return keys
 }
 
-type loadedPackageGenerator interface {
-	generateViaLoadedPackage(writer io.Writer, pkg *packages.Package) error
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
 }
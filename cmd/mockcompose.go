@@ -1,16 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
-	yaml "gopkg.in/yaml.v2"
+	yaml "gopkg.in/yaml.v3"
 
 	"github.com/kelveny/mockcompose/pkg/gofile"
+	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/goload"
 	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
 )
 
 var SemVer = "v0.0.0-devel"
@@ -21,122 +28,271 @@ func GetSemverInfo() string {
 
 func usage() {
 	logger.Log(logger.PROMPT, `Usage: %s [-help] [options]
+       %s validate [options] [configuration files or directories]
 
 mockcompose generates mocking implementation for Go classes, interfaces and functions.
-`, os.Args[0])
+`, os.Args[0], os.Args[0])
 	flag.PrintDefaults()
-	os.Exit(1)
 }
 
-func loadConfig() *Config {
+// exit codes of mockcompose, when errors of different kinds occur, usage errors
+// take precedence over source errors, which take precedence over failures
+const (
+	exitFailure = 1 // code can not be generated, or generated files are stale
+	exitUsage   = 2 // invalid command line options or configuration
+	exitSource  = 3 // source code can not be parsed or type checked
+)
+
+// exitCode returns exit code of err, which may join errors of multiple entries
+func exitCode(err error) int {
+	switch errorKind(err) {
+	case 0:
+		return 0
+	case mockcompose.UsageError:
+		return exitUsage
+	case mockcompose.SourceError:
+		return exitSource
+	}
+	return exitFailure
+}
+
+// errorKind returns the kind that takes precedence among errors joined in err,
+// errors that do not come from generation are taken as generation errors
+func errorKind(err error) mockcompose.Kind {
+	if err == nil {
+		return 0
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var kind mockcompose.Kind
+		for _, e := range joined.Unwrap() {
+			if k := errorKind(e); k > kind {
+				kind = k
+			}
+		}
+		return kind
+	}
+
+	var genErr *mockcompose.Error
+	if errors.As(err, &genErr) {
+		return genErr.Kind()
+	}
+	return mockcompose.GenerationError
+}
+
+// usageError returns an error of invalid options or configuration
+func usageError(msg string) error {
+	return &mockcompose.Error{
+		Diagnostics: []mockcompose.Diagnostic{
+			{Level: logger.ERROR, Kind: mockcompose.UsageError, Message: msg},
+		},
+	}
+}
+
+var configFileNames = []string{".mockcompose.yaml", ".mockcompose.yml"}
+
+func loadConfig() (*Config, error) {
 	pkgDir, err := filepath.Abs("")
-	logger.Log(logger.VERBOSE, "Check directory %s for YAML configuration\n", pkgDir)
 	if err != nil {
 		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
-		os.Exit(1)
+		return nil, err
 	}
 
-	if cfg := loadYamlConfig(filepath.Join(pkgDir, ".mockcompose.yaml")); cfg != nil {
-		return cfg
-	}
+	return loadConfigInDir(pkgDir)
+}
 
-	if cfg := loadYamlConfig(filepath.Join(pkgDir, ".mockcompose.yml")); cfg != nil {
-		return cfg
+// loadConfigInDir loads YAML configuration in pkgDir, entries take defaults of
+// the configuration and its ancestors. It returns nil if there is no configuration
+func loadConfigInDir(pkgDir string) (*Config, error) {
+	cfg, err := loadConfigDefaults(pkgDir)
+	if cfg != nil {
+		cfg.applyDefaults()
 	}
-
-	return nil
+	return cfg, err
 }
 
-func loadYamlConfig(yamlFile string) *Config {
-	yamlConfig, err := ioutil.ReadFile(yamlFile)
-	if err == nil {
-		cfg := Config{}
+// loadConfigDefaults loads YAML configuration in pkgDir with defaults inherited
+// from the nearest ancestor configuration
+func loadConfigDefaults(pkgDir string) (*Config, error) {
+	logger.Log(logger.VERBOSE, "Check directory %s for YAML configuration\n", pkgDir)
 
-		err := yaml.Unmarshal(yamlConfig, &cfg)
-		if err != nil {
-			logger.Log(logger.ERROR, "Failed to load YAML config: %s\n", err)
+	for _, name := range configFileNames {
+		cfg, err := loadConfigFile(filepath.Join(pkgDir, name))
+		if err != nil || cfg != nil {
+			return cfg, err
 		}
-		return &cfg
 	}
 
-	return nil
+	return nil, nil
+}
+
+// loadConfigFile loads YAML configuration of yamlFile with defaults inherited
+// from the nearest ancestor configuration of its directory, defaults are not
+// applied to entries yet. It returns nil if yamlFile does not exist
+func loadConfigFile(yamlFile string) (*Config, error) {
+	cfg, err := loadYamlConfig(yamlFile)
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+
+	if err := cfg.inheritDefaults(filepath.Dir(yamlFile)); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
-func executeOptions(options *CommandOptions) {
-	var g parsedFileGenerator
+// inheritDefaults resolves defaults of configuration in dir, and takes defaults
+// that are not set from the nearest ancestor configuration
+func (cfg *Config) inheritDefaults(dir string) error {
+	cfg.Defaults.resolve(dir)
 
-	if options.ClzName != "" || len(options.MethodsToClone) > 0 {
-		if len(options.MethodsToClone) == 0 {
-			logger.Log(logger.ERROR, "Please specify at least one real method name with -real option\n")
-			os.Exit(1)
-		}
+	if parentDir := findParentConfigDir(dir); parentDir != "" {
+		logger.Log(logger.VERBOSE, "Inherit defaults of YAML configuration in %s\n", parentDir)
 
-		g = &classMethodGenerator{
-			clzName:        options.ClzName,
-			mockPkgName:    options.MockPkg,
-			mockName:       options.MockName,
-			methodsToClone: options.MethodsToClone,
-			methodsToMock:  options.MethodsToMock,
+		parent, err := loadConfigDefaults(parentDir)
+		if err != nil {
+			return err
 		}
-	} else if options.IntfName != "" {
-		g = &interfaceMockGenerator{
-			mockPkgName: options.MockPkg,
-			mockName:    options.MockName,
-			intfName:    options.IntfName,
-			srcPkg:      options.SrcPkg,
+		if parent != nil {
+			cfg.Defaults.inherit(parent.Defaults)
 		}
+	}
+	return nil
+}
 
-		if options.SrcPkg != "" {
-			scanPackageToGenerate(g.(loadedPackageGenerator), options)
-			return
-		}
-	} else {
-		if len(options.MethodsToMock) == 0 && len(options.MethodsToClone) == 0 {
-			logger.Log(logger.ERROR, "no function to mock or clone\n")
-			os.Exit(1)
+// findParentConfigDir returns the nearest ancestor directory of dir that contains
+// YAML configuration, the search stops at root of the repository, which is the
+// directory that contains .git
+func findParentConfigDir(dir string) string {
+	for !isRepositoryRoot(dir) {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
 		}
 
-		if len(options.MethodsToMock) > 0 && len(options.MethodsToClone) > 0 {
-			logger.Log(logger.ERROR, "option -real and option -mock are exclusive in function clone generation\n")
-			os.Exit(1)
+		dir = parent
+		if hasConfig(dir) {
+			return dir
 		}
+	}
+	return ""
+}
 
-		if len(options.MethodsToClone) > 0 {
-			// pure function clone is now deprecated
-			if options.SrcPkg != "" {
-				logger.Log(logger.PROMPT,
-					"No source package support in function clone generation, ignore source package %s\n",
-					options.SrcPkg)
-			}
-			g = &functionCloneGenerator{
-				mockPkgName:    options.MockPkg,
-				mockName:       options.MockName,
-				methodsToClone: options.MethodsToClone,
-			}
-		} else {
-			g = &functionMockGenerator{
-				mockPkgName:   options.MockPkg,
-				mockName:      options.MockName,
-				methodsToMock: options.MethodsToMock,
-				srcPkg:        options.SrcPkg,
-			}
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// loadYamlConfig loads YAML configuration of yamlFile, it returns nil if yamlFile
+// does not exist, and a usage error if the configuration is invalid
+func loadYamlConfig(yamlFile string) (*Config, error) {
+	if fi, err := os.Stat(yamlFile); errors.Is(err, fs.ErrNotExist) || err == nil && fi.IsDir() {
+		return nil, nil
+	}
+
+	yamlConfig, err := ioutil.ReadFile(yamlFile)
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in reading file %s, error: %s\n", yamlFile, err)
+		return nil, err
+	}
+
+	cfg := Config{}
+	if err := yaml.Unmarshal(yamlConfig, &cfg); err != nil {
+		logger.Log(logger.ERROR, "Failed to load YAML config: %s\n", err)
+		return nil, usageError(fmt.Sprintf("failed to load YAML config %s: %s", yamlFile, err))
+	}
+	return &cfg, nil
+}
 
-			if options.SrcPkg != "" {
-				scanPackageToGenerate(g.(loadedPackageGenerator), options)
-				return
+// executeOptions executes a configuration entry, generated files are handed over
+// to sink, messages are logged with log so that entries executed concurrently can
+// collect their own diagnostics. It returns the generated files
+func executeOptions(options *CommandOptions, sink outputSink, log *logger.Logger) ([]mockcompose.GeneratedFile, error) {
+	entry := *options
+	entry.Logger = log.With("entry", options.MockName)
+	// packages are loaded once for all entries
+	entry.Loader = goload.Default
+
+	files, err := mockcompose.Generate(context.Background(), entry)
+	for _, file := range files {
+		if werr := sink.write(file.Path, file.Content); werr != nil {
+			log.Log(logger.ERROR, "Error in writing %s, error: %s\n", file.Path, werr)
+			if err == nil {
+				err = werr
 			}
 		}
 	}
+	return files, err
+}
+
+// configureLogger configures the default logger with command line options, it
+// returns usage error if options are invalid
+func configureLogger(verbose bool, quiet bool, logFormat string) error {
+	format, err := logger.ParseFormat(logFormat)
+	if err != nil {
+		logger.Log(logger.ERROR, "%s\n", err)
+		return usageError(err.Error())
+	}
+	logger.Default = logger.Default.WithFormat(format)
 
-	scanCWDToGenerate(g, options)
+	if verbose && quiet {
+		logger.Log(logger.ERROR, "option -v and option -q are exclusive\n")
+		return usageError("option -v and option -q are exclusive")
+	}
+
+	if verbose {
+		logger.Default = logger.Default.WithLevel(logger.VERBOSE)
+
+		logger.Log(logger.VERBOSE, "Set logging to verbose mode\n")
+	} else if quiet {
+		logger.Default = logger.Default.WithLevel(logger.WARN)
+	}
+	goload.Default.SetLogger(logger.Default)
+	return nil
 }
 
+// Execute executes mockcompose command, errors of the command are mapped to exit
+// codes here
 func Execute() {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		err = executeValidate(os.Args[2:])
+	} else {
+		err = executeGenerate()
+	}
+
+	if err != nil {
+		os.Exit(exitCode(err))
+	}
+}
+
+// executeGenerate executes mockcompose command with command line options, errors
+// that occur after report is requested are written to the report as well
+func executeGenerate() error {
+
 	var methodsToClone stringSlice
 	var methodsToMock stringSlice
 
+	var only stringSlice
+
 	vb := flag.Bool("v", false, "if set, print verbose logging messages")
+	quiet := flag.Bool("q", false, "if set, print warnings and errors only")
+	logFormat := flag.String("log-format", "text", "format of logging messages, text or kv for key=value pairs")
+	configFile := flag.String("config", "", "path of the YAML configuration to execute instead of the one in current working directory")
+	noConfig := flag.Bool("no-config", false, "if set, ignore YAML configuration and generate with command line options only")
+	flag.Var(&only, "only", "name of the YAML configuration entry to execute, all entries are executed if not set")
+	recursive := flag.Bool("r", false, "if set, execute YAML configurations found in directories matched by arguments, i.e., ./...")
+	checkOnly := flag.Bool("check", false, "if set, compare generated code with existing files instead of writing them, exit with non-zero status if any is stale")
+	diff := flag.Bool("diff", false, "if set, print unified diff between existing files and generated code instead of writing them")
+	output := flag.String("o", "", "path of the generated file or the directory it resides, - to write generated code to stdout")
+	reportFormat := flag.String("report", "", "format of the generation report to write to stdout, or to the file set by -report-file, only json is supported")
+	reportFile := flag.String("report-file", "", "path of the file to write the generation report to, in json format unless -report is set")
+	jobs := flag.Int("j", 1, "number of YAML configuration entries to execute in parallel")
 	testOnly := flag.Bool("testonly", true, "if set, append _test to generated file name")
+	expecter := flag.Bool("expecter", false, "if set, generate typed EXPECT() API for mocked methods")
+	spy := flag.Bool("spy", false, "if set, generate interface mock that delegates to a real implementation unless expectations are set up")
+	tmpl := flag.String("template", "", "path of the text/template file to render generated code with")
+	backend := flag.String("backend", gogen.DefaultBackend, "mocking framework of generated code, testify, gomock or fake")
 	prtVersion := flag.Bool("version", false, "if set, print version information")
 	help := flag.Bool("help", false, "if set, print usage information")
 	mockName := flag.String("n", "", "name of the generated class")
@@ -151,57 +307,297 @@ func Execute() {
 
 	if *prtVersion {
 		fmt.Println(GetSemverInfo())
-		os.Exit(0)
+		return nil
 	}
 
 	if *help {
 		usage()
-		os.Exit(0)
+		return nil
 	}
 
-	if *vb {
-		logger.LogLevel = int(logger.VERBOSE)
+	if err := configureLogger(*vb, *quiet, *logFormat); err != nil {
+		return err
+	}
 
-		logger.Log(logger.VERBOSE, "Set logging to verbose mode\n")
+	if err := checkArgs(flag.Args(), *recursive); err != nil {
+		logger.Log(logger.ERROR, "%s\n", err)
+		return usageError(err.Error())
+	}
+
+	toStdout := *output == "-"
+	if toStdout && (*checkOnly || *diff) {
+		logger.Log(logger.ERROR, "option -o - is exclusive with -check and -diff\n")
+		return usageError("option -o - is exclusive with -check and -diff")
 	}
 
-	if cfg := loadConfig(); cfg != nil {
-		logger.Log(logger.VERBOSE, "Found mockcompose YAML configuration, ignore command line options\n")
+	if *reportFormat != "" && *reportFormat != "json" {
+		logger.Log(logger.ERROR, "unsupported report format %s, only json is supported\n", *reportFormat)
+		return usageError(fmt.Sprintf("unsupported report format %s", *reportFormat))
+	}
+
+	reportToStdout := *reportFormat != "" && *reportFile == ""
+	if reportToStdout && (toStdout || *diff) {
+		logger.Log(logger.ERROR, "option -report without -report-file is exclusive with -o - and -diff\n")
+		return usageError("option -report without -report-file is exclusive with -o - and -diff")
+	}
+
+	var sink outputSink = fileSink{}
+	var memory *memorySink
+	if toStdout || *checkOnly || *diff {
+		memory = newMemorySink()
+		sink = memory
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return err
+	}
+
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if err := checkConfigOptions(set, *recursive, *configFile, *noConfig, only); err != nil {
+		logger.Log(logger.ERROR, "%s\n", err)
+		return usageError(err.Error())
+	}
+
+	var report *runReport
+	if *reportFormat != "" || *reportFile != "" {
+		mode := "write"
+		switch {
+		case toStdout:
+			mode = "stdout"
+		case *checkOnly:
+			mode = "check"
+		case *diff:
+			mode = "diff"
+		}
+		report = newRunReport(mode, cwd)
+	}
+
+	var cfg *Config
+	if *configFile != "" {
+		path, err := filepath.Abs(*configFile)
+		if err == nil {
+			cfg, err = loadConfigFile(path)
+		}
+		if err == nil && cfg == nil {
+			err = usageError("no YAML config " + *configFile)
+		}
+		if err != nil {
+			logger.Log(logger.ERROR, "Failed to load YAML config %s\n", *configFile)
+			return finishReport(report, *reportFile, err)
+		}
+	} else if !*noConfig && !*recursive {
+		if cfg, err = loadConfig(); err != nil {
+			return finishReport(report, *reportFile, err)
+		}
+	}
+
+	if *tmpl != "" && !filepath.IsAbs(*tmpl) {
+		// command line options apply to entries executed in other directories
+		if path, err := filepath.Abs(*tmpl); err == nil {
+			*tmpl = path
+		}
+	}
+
+	overrideOutput := *output
+	if toStdout {
+		overrideOutput = ""
+	}
+	sel := newSelection(CommandOptions{
+		MockPkg:  *mockPkg,
+		TestOnly: *testOnly,
+		Expecter: *expecter,
+		Spy:      *spy,
+		Backend:  *backend,
+		Template: *tmpl,
+		Output:   overrideOutput,
+	}, set, only)
+
+	if *recursive {
+		err = executeRecursively(flag.Args(), *jobs, sink, report, sel)
+	} else if cfg != nil && *mockName == "" {
+		logger.Log(logger.VERBOSE, "Found mockcompose YAML configuration, apply command line options on top of it\n")
+
+		entries := sel.entries(cfg)
+		if unmatched := sel.unmatched(); len(unmatched) > 0 {
+			logger.Log(logger.ERROR, "No configuration entry named %s\n", strings.Join(unmatched, ", "))
+			return finishReport(report, *reportFile, usageError("no configuration entry named "+strings.Join(unmatched, ", ")))
+		}
+
+		_, err = executeEntries(entries, gofile.DerivePackage(false), *jobs, sink, report)
+	} else {
+		if *mockName == "" {
+			usage()
+			return finishReport(report, *reportFile, usageError("name of the generated class is not specified"))
+		}
+
+		if toStdout {
+			*output = ""
+		}
+
+		options := &CommandOptions{
+			MockName:       *mockName,
+			MockPkg:        *mockPkg,
+			ClzName:        *clzName,
+			IntfName:       *intfName,
+			SrcPkg:         *srcPkg,
+			TestOnly:       *testOnly,
+			Expecter:       *expecter,
+			Spy:            *spy,
+			Backend:        *backend,
+			Template:       *tmpl,
+			Output:         *output,
+			MethodsToClone: methodsToClone,
+			MethodsToMock:  methodsToMock,
+		}
 
-		derivedPkg := gofile.DerivePackage(false)
-		for _, options := range cfg.Mockcompose {
-			if options.MockPkg == "" {
-				options.MockPkg = derivedPkg
+		if cfg != nil {
+			// command line describes the entry, it takes defaults of configuration
+			logger.Log(logger.VERBOSE, "Found mockcompose YAML configuration, apply its defaults to command line options\n")
+
+			entryCfg := &Config{
+				Defaults:    cfg.Defaults,
+				Mockcompose: []CommandOptions{*options},
+				keys:        []map[string]bool{sel.setKeys()},
 			}
+			entryCfg.applyDefaults()
+			options = &entryCfg.Mockcompose[0]
+		}
+
+		if options.MockPkg == "" {
+			options.MockPkg = gofile.DerivePackage(false)
 
-			executeOptions(&options)
+			logger.Log(logger.VERBOSE, "Derive package name as: %s\n", options.MockPkg)
 		}
+		logger.Log(logger.PROMPT, "\n")
 
-		return
+		var files []mockcompose.GeneratedFile
+		files, err = executeOptions(options, sink, logger.Default)
+		report.addEntry(options, files, err)
 	}
 
-	if *mockPkg == "" {
-		*mockPkg = gofile.DerivePackage(false)
+	if memory != nil {
+		var werr error
+		if toStdout {
+			werr = memory.writeContents(os.Stdout)
+		} else if *diff {
+			werr = memory.writeDiffs(os.Stdout, cwd)
+		}
+		if werr != nil {
+			logger.Log(logger.ERROR, "Error in writing output. error: %s\n", werr)
+			err = errors.Join(err, werr)
+		}
 
-		logger.Log(logger.VERBOSE, "Derive package name as: %s\n", *mockPkg)
+		if *checkOnly {
+			err = errors.Join(err, reportStaleFiles(memory, cwd, report))
+		}
 	}
-	fmt.Println()
 
-	if *mockName == "" {
-		usage()
-		os.Exit(1)
+	return finishReport(report, *reportFile, err)
+}
+
+// finishReport writes report with exit code of err if report is requested, it
+// returns err together with error of writing report
+func finishReport(report *runReport, reportFile string, err error) error {
+	if report != nil {
+		report.ExitCode = exitCode(err)
+		if werr := writeReport(report, reportFile); werr != nil {
+			logger.Log(logger.ERROR, "Error in writing report. error: %s\n", werr)
+			err = errors.Join(err, werr)
+		}
+	}
+	return err
+}
+
+// writeReport writes report to file, or to stdout if file is empty
+func writeReport(report *runReport, file string) error {
+	if file == "" {
+		return report.write(os.Stdout)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if err := report.write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// checkConfigOptions checks command line options that select configuration, set
+// contains names of options that are set
+func checkConfigOptions(set map[string]bool, recursive bool, configFile string, noConfig bool, only []string) error {
+	if configFile != "" && noConfig {
+		return errors.New("option -config and option -no-config are exclusive")
+	}
+
+	if recursive && (configFile != "" || noConfig || set["n"]) {
+		return errors.New("option -r is exclusive with -config, -no-config and -n")
 	}
 
-	options := &CommandOptions{
-		MockName:       *mockName,
-		MockPkg:        *mockPkg,
-		ClzName:        *clzName,
-		IntfName:       *intfName,
-		SrcPkg:         *srcPkg,
-		TestOnly:       *testOnly,
-		MethodsToClone: methodsToClone,
-		MethodsToMock:  methodsToMock,
+	if len(only) > 0 && (noConfig || set["n"]) {
+		return errors.New("option -only is exclusive with -no-config and -n")
 	}
 
-	executeOptions(options)
+	if !set["n"] {
+		for _, name := range []string{"c", "i", "p", "real", "mock"} {
+			if set[name] {
+				return fmt.Errorf("option -%s requires -n", name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkArgs checks arguments that follow command line options, they are only
+// taken as directory patterns with -r
+func checkArgs(args []string, recursive bool) error {
+	if !recursive && len(args) > 0 && args[0] == "validate" {
+		return errors.New("validate subcommand must precede options, i.e., mockcompose validate -q ./...")
+	}
+	return nil
+}
+
+// reportStaleFiles logs generated files that are out of date or missing in check
+// mode with paths relative to cwd, files are also added to report if requested.
+// It returns an error if there is any
+func reportStaleFiles(memory *memorySink, cwd string, report *runReport) error {
+	stale, missing, err := memory.staleFiles()
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return err
+	}
+
+	for _, files := range []struct {
+		kind  string
+		paths []string
+	}{{"Stale", stale}, {"Missing", missing}} {
+		for _, path := range files.paths {
+			path = relPath(cwd, path)
+			logger.Log(logger.ERROR, "%s generated file: %s\n", files.kind, path)
+
+			if report != nil {
+				if files.kind == "Stale" {
+					report.Stale = append(report.Stale, path)
+				} else {
+					report.Missing = append(report.Missing, path)
+				}
+			}
+		}
+	}
+
+	if len(stale) > 0 || len(missing) > 0 {
+		return fmt.Errorf("%d stale and %d missing generated files", len(stale), len(missing))
+	}
+
+	logger.Log(logger.PROMPT, "Generated files are up to date\n")
+	return nil
 }
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func Test_exitCode(t *testing.T) {
+	assert := require.New(t)
+
+	sourceErr := &mockcompose.Error{
+		Diagnostics: []mockcompose.Diagnostic{
+			{Level: logger.WARN, Message: "warning"},
+			{Level: logger.ERROR, Kind: mockcompose.SourceError, Message: "parse error"},
+		},
+	}
+
+	assert.Equal(0, exitCode(nil))
+	assert.Equal(exitFailure, exitCode(errors.New("write error")))
+	assert.Equal(exitSource, exitCode(sourceErr))
+	assert.Equal(exitUsage, exitCode(usageError("output conflict")))
+	assert.Equal(exitSource, exitCode(errors.Join(errors.New("write error"), sourceErr)))
+	assert.Equal(exitUsage, exitCode(errors.Join(sourceErr, errors.Join(usageError("output conflict")))))
+}
+
+func Test_loadConfigInDir(t *testing.T) {
+	assert := require.New(t)
+
+	root := t.TempDir()
+	for p, content := range map[string]string{
+		".git/HEAD": "",
+		".mockcompose.yaml": `defaults:
+  testOnly: true
+  backend: gomock
+  template: tools/mock.tmpl
+`,
+		"pkg/.mockcompose.yaml": `defaults:
+  output: mocks
+mockcompose:
+  - name: mockFoo
+    interfaceName: Foo
+  - name: mockBar
+    interfaceName: Bar
+    testOnly: false
+    backend: testify
+    output: bar.go
+`,
+	} {
+		assert.NoError(os.MkdirAll(filepath.Join(root, filepath.Dir(p)), 0755))
+		assert.NoError(os.WriteFile(filepath.Join(root, p), []byte(content), 0644))
+	}
+
+	cfg, err := loadConfigInDir(filepath.Join(root, "pkg"))
+	assert.NoError(err)
+	assert.NotNil(cfg)
+	assert.Equal([]CommandOptions{
+		{
+			MockName: "mockFoo",
+			IntfName: "Foo",
+			TestOnly: true,
+			Backend:  "gomock",
+			Template: filepath.Join(root, "tools", "mock.tmpl"),
+			Output:   "mocks/",
+		},
+		{
+			MockName: "mockBar",
+			IntfName: "Bar",
+			Backend:  "testify",
+			Template: filepath.Join(root, "tools", "mock.tmpl"),
+			Output:   "bar.go",
+		},
+	}, cfg.Mockcompose)
+	assert.Equal("", findParentConfigDir(root))
+
+	cfg, err = loadConfigInDir(filepath.Join(root, "tools"))
+	assert.NoError(err)
+	assert.Nil(cfg)
+
+	// invalid configuration is a usage error, so is invalid configuration of ancestors
+	assert.NoError(os.WriteFile(filepath.Join(root, ".mockcompose.yaml"), []byte("defaults: [\n"), 0644))
+	_, err = loadConfigInDir(filepath.Join(root, "pkg"))
+	assert.Error(err)
+	assert.Equal(exitUsage, exitCode(err))
+}
+
+func Test_selection(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &Config{
+		Mockcompose: []CommandOptions{
+			{MockName: "mockFmt", SrcPkg: "fmt", TestOnly: true, Backend: "gomock"},
+			{MockName: "mockJson", SrcPkg: "encoding/json", TestOnly: true},
+		},
+	}
+
+	sel := newSelection(CommandOptions{MockPkg: "foo", Backend: "testify"},
+		map[string]bool{"testonly": true, "pkg": true, "v": true}, []string{"mockFmt", "mockFoo"})
+
+	assert.Equal([]CommandOptions{
+		{MockName: "mockFmt", MockPkg: "foo", SrcPkg: "fmt", Backend: "gomock"},
+	}, sel.entries(cfg))
+	assert.Equal([]string{"mockFoo"}, sel.unmatched())
+	assert.Equal(map[string]bool{"testOnly": true, "mockPkg": true}, sel.setKeys())
+}
+
+func Test_applyDefaults_testOnly(t *testing.T) {
+	assert := require.New(t)
+
+	var cfg Config
+	assert.NoError(yaml.Unmarshal([]byte(`mockcompose:
+  - name: mockFoo
+    interfaceName: Foo
+  - name: mockBar
+    interfaceName: Bar
+    testOnly: false
+`), &cfg))
+	cfg.applyDefaults()
+	assert.True(cfg.Mockcompose[0].TestOnly)
+	assert.False(cfg.Mockcompose[1].TestOnly)
+
+	testOnly := false
+	cfg.Defaults.TestOnly = &testOnly
+	cfg.Mockcompose[0].TestOnly = false
+	cfg.applyDefaults()
+	assert.False(cfg.Mockcompose[0].TestOnly)
+}
+
+func Test_checkArgs(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(checkArgs(nil, false))
+	assert.NoError(checkArgs([]string{"validate"}, true))
+	assert.EqualError(checkArgs([]string{"validate", "./..."}, false),
+		"validate subcommand must precede options, i.e., mockcompose validate -q ./...")
+}
+
+func Test_checkConfigOptions(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(checkConfigOptions(map[string]bool{"n": true, "i": true}, false, "", true, nil))
+	assert.NoError(checkConfigOptions(map[string]bool{}, true, "", false, []string{"mockFmt"}))
+	assert.Error(checkConfigOptions(map[string]bool{}, false, "a.yaml", true, nil))
+	assert.Error(checkConfigOptions(map[string]bool{}, true, "a.yaml", false, nil))
+	assert.Error(checkConfigOptions(map[string]bool{"n": true}, false, "", false, []string{"mockFmt"}))
+	assert.Error(checkConfigOptions(map[string]bool{"i": true}, false, "", false, nil))
+}
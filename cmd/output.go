@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// outputSink receives formatted content of generated files, all generators hand
+// their output over to a sink instead of writing files by themselves
+type outputSink interface {
+	write(fileName string, content []byte) error
+}
+
+// fileSink writes generated content to files, missing directories are created
+type fileSink struct{}
+
+func (fileSink) write(fileName string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0644)
+}
+
+// memorySink collects generated content in memory instead of writing files, it
+// is shared by entries that run in parallel and is reported once all are done
+type memorySink struct {
+	mutex sync.Mutex
+	files map[string][]byte // absolute file path -> generated content
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{
+		files: make(map[string][]byte),
+	}
+}
+
+func (s *memorySink) write(fileName string, content []byte) error {
+	path, err := filepath.Abs(fileName)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.files[path] = content
+	return nil
+}
+
+// paths returns absolute paths of collected files in sorted order
+func (s *memorySink) paths() []string {
+	paths := make([]string, 0, len(s.files))
+	for path := range s.files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+// writeContents writes collected content to writer in order of file paths
+func (s *memorySink) writeContents(writer io.Writer) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, path := range s.paths() {
+		if _, err := writer.Write(s.files[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDiffs writes unified diffs between existing files and collected content,
+// file names in diff headers are relative to baseDir
+func (s *memorySink) writeDiffs(writer io.Writer, baseDir string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, path := range s.paths() {
+		name := path
+		if rel, err := filepath.Rel(baseDir, path); err == nil {
+			name = rel
+		}
+
+		fromFile := "a/" + filepath.ToSlash(name)
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			fromFile = "/dev/null"
+		}
+
+		if bytes.Equal(existing, s.files[path]) {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(existing),
+			B:        splitLines(s.files[path]),
+			FromFile: fromFile,
+			ToFile:   "b/" + filepath.ToSlash(name),
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprint(writer, diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// staleFiles returns absolute paths of generated files that are out of date and
+// paths of the ones that do not exist, in sorted order
+func (s *memorySink) staleFiles() (stale []string, missing []string, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, path := range s.paths() {
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				missing = append(missing, path)
+				continue
+			}
+			return nil, nil, err
+		}
+
+		if !bytes.Equal(existing, s.files[path]) {
+			stale = append(stale, path)
+		}
+	}
+
+	return stale, missing, nil
+}
+
+// splitLines splits content into lines that keep their line endings
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_memorySink(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "current.go"), []byte("package foo\n"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "stale.go"), []byte("package foo\n\nvar x = 1\n"), 0644))
+
+	sink := newMemorySink()
+	assert.NoError(sink.write(filepath.Join(dir, "current.go"), []byte("package foo\n")))
+	assert.NoError(sink.write(filepath.Join(dir, "stale.go"), []byte("package foo\n\nvar x = 2\n")))
+	assert.NoError(sink.write(filepath.Join(dir, "missing.go"), []byte("package foo\n")))
+
+	stale, missing, err := sink.staleFiles()
+	assert.NoError(err)
+	assert.Equal([]string{filepath.Join(dir, "stale.go")}, stale)
+	assert.Equal([]string{filepath.Join(dir, "missing.go")}, missing)
+
+	var diff bytes.Buffer
+	assert.NoError(sink.writeDiffs(&diff, dir))
+	assert.Equal(`--- /dev/null
++++ b/missing.go
+@@ -0,0 +1 @@
++package foo
+--- a/stale.go
++++ b/stale.go
+@@ -1,3 +1,3 @@
+ package foo
+ 
+-var x = 1
++var x = 2
+`, diff.String())
+
+	var contents bytes.Buffer
+	assert.NoError(sink.writeContents(&contents))
+	assert.Equal("package foo\npackage foo\npackage foo\n\nvar x = 2\n", contents.String())
+
+	content, err := os.ReadFile(filepath.Join(dir, "stale.go"))
+	assert.NoError(err)
+	assert.Equal("package foo\n\nvar x = 1\n", string(content))
+}
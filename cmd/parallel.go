@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
+)
+
+// entryResult records execution of a configuration entry, messages are
+// collected per entry and written to the default logger in entry order
+type entryResult struct {
+	options CommandOptions
+	files   []mockcompose.GeneratedFile
+	output  bytes.Buffer
+	err     error
+}
+
+// findOutputConflicts returns messages of entries that target the same output
+// file, paths of output files are relative to Dir of entries
+func findOutputConflicts(entries []CommandOptions) []string {
+	targets := make(map[string][]int)
+	for i := range entries {
+		name := entries[i].OutputFileName()
+		targets[name] = append(targets[name], i)
+	}
+
+	conflicts := []string{}
+	for name, indexes := range targets {
+		if len(indexes) > 1 {
+			names := make([]string, len(indexes))
+			for i, index := range indexes {
+				names[i] = fmt.Sprintf("#%d %s", index+1, entries[index].MockName)
+			}
+			conflicts = append(conflicts, fmt.Sprintf("%s is generated by entries %s",
+				name, strings.Join(names, ", ")))
+		}
+	}
+
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// executeEntries executes configuration entries with at most jobs entries in
+// parallel, entries with empty package name take derivedPkg. It returns errors of
+// all failed entries, or a usage error if entries conflict with each other.
+// Executed entries are added to report in entry order
+func executeEntries(
+	entries []CommandOptions,
+	derivedPkg string,
+	jobs int,
+	sink outputSink,
+	report *runReport,
+) ([]*entryResult, error) {
+	results := make([]*entryResult, len(entries))
+	for i, options := range entries {
+		if options.MockPkg == "" {
+			options.MockPkg = derivedPkg
+		}
+		results[i] = &entryResult{options: options}
+	}
+
+	options := make([]CommandOptions, len(results))
+	for i, result := range results {
+		options[i] = result.options
+	}
+
+	if conflicts := findOutputConflicts(options); len(conflicts) > 0 {
+		errs := []error{}
+		for _, conflict := range conflicts {
+			logger.Log(logger.ERROR, "Output conflict: %s\n", conflict)
+			errs = append(errs, usageError("output conflict: "+conflict))
+		}
+		err := errors.Join(errs...)
+		for _, result := range results {
+			report.addEntry(&result.options, nil, err)
+		}
+		return results, err
+	}
+
+	if jobs <= 1 {
+		// log messages as they come when entries are executed one by one
+		for _, result := range results {
+			result.files, result.err = executeOptions(&result.options, sink, logger.Default)
+		}
+	} else {
+		sem := make(chan struct{}, jobs)
+		var wg sync.WaitGroup
+
+		for _, result := range results {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func(result *entryResult) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				result.files, result.err = executeOptions(&result.options, sink, logger.Default.WithWriter(&result.output))
+			}(result)
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			logger.Default.Write(result.output.Bytes())
+		}
+	}
+
+	errs := []error{}
+	for _, result := range results {
+		report.addEntry(&result.options, result.files, result.err)
+		if result.err != nil {
+			errs = append(errs, result.err)
+		}
+	}
+
+	if len(errs) > 0 {
+		logger.Log(logger.ERROR, "%d of %d entries failed\n", len(errs), len(results))
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findOutputConflicts(t *testing.T) {
+	assert := require.New(t)
+
+	entries := []CommandOptions{
+		{MockName: "mockFoo", TestOnly: true},
+		{MockName: "mockBar", TestOnly: true},
+		{MockName: "mockFoo", TestOnly: false},
+		{MockName: "mockFoo", TestOnly: true},
+	}
+
+	assert.Equal([]string{
+		"mockc_mockFoo_test.go is generated by entries #1 mockFoo, #4 mockFoo",
+	}, findOutputConflicts(entries))
+
+	assert.Empty(findOutputConflicts(entries[:3]))
+}
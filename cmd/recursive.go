@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kelveny/mockcompose/pkg/gofile"
+	"github.com/kelveny/mockcompose/pkg/logger"
+)
+
+// dirSummary records configuration entries executed in a directory
+type dirSummary struct {
+	dir     string
+	entries int
+	outputs []string
+	err     error
+}
+
+// executeRecursively discovers YAML configurations in directories matched by
+// patterns and executes them with the directory as working context, patterns
+// are in format of go tool package patterns, i.e., ./... or ./pkg/..., it returns
+// errors of all directories in which execution fails. Entries are selected and
+// take command line options with sel
+func executeRecursively(patterns []string, jobs int, sink outputSink, report *runReport, sel *selection) error {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	dirs, err := findConfigDirs(patterns)
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return err
+	}
+
+	if len(dirs) == 0 {
+		logger.Log(logger.WARN, "No mockcompose YAML configuration found in %s\n", strings.Join(patterns, " "))
+		return nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return err
+	}
+
+	summaries := []*dirSummary{}
+	for _, dir := range dirs {
+		summaries = append(summaries, executeConfigInDir(dir, jobs, sink, report, sel))
+	}
+
+	errs := []error{}
+	logger.Log(logger.PROMPT, "Summary:\n")
+	for _, summary := range summaries {
+		rel, err := filepath.Rel(cwd, summary.dir)
+		if err != nil {
+			rel = summary.dir
+		}
+
+		if summary.err == nil {
+			logger.Log(logger.PROMPT, "  %s: %d entries, %s\n", rel, summary.entries, strings.Join(summary.outputs, ", "))
+		} else {
+			errs = append(errs, summary.err)
+			logger.Log(logger.ERROR, "  %s: %d entries, failed\n", rel, summary.entries)
+		}
+	}
+
+	if unmatched := sel.unmatched(); len(unmatched) > 0 {
+		logger.Log(logger.ERROR, "No configuration entry named %s\n", strings.Join(unmatched, ", "))
+		errs = append(errs, usageError("no configuration entry named "+strings.Join(unmatched, ", ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// executeConfigInDir executes YAML configuration in dir with dir as working context
+// of its entries
+func executeConfigInDir(dir string, jobs int, sink outputSink, report *runReport, sel *selection) *dirSummary {
+	summary := &dirSummary{dir: dir}
+
+	cfg, err := loadConfigInDir(dir)
+	if cfg == nil {
+		summary.err = err
+		return summary
+	}
+
+	logger.Log(logger.PROMPT, "Execute mockcompose YAML configuration in %s...\n\n", dir)
+
+	entries := sel.entries(cfg)
+	for i := range entries {
+		entries[i].Dir = dir
+	}
+
+	results, err := executeEntries(entries, gofile.DeriveDirPackage(dir, false), jobs, sink, report)
+	for _, result := range results {
+		summary.entries++
+		summary.outputs = append(summary.outputs, relPath(dir, result.options.OutputFileName()))
+	}
+	summary.err = err
+
+	return summary
+}
+
+// findConfigDirs returns absolute paths of directories that contain YAML configuration,
+// following go tool conventions, directories and files that begin with . or _ and
+// directories named testdata or vendor are skipped when walking
+func findConfigDirs(patterns []string) ([]string, error) {
+	dirs := []string{}
+	found := make(map[string]bool)
+
+	add := func(dir string) {
+		if !found[dir] && hasConfig(dir) {
+			found[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "/...")
+		if pattern == "..." {
+			root, recursive = ".", true
+		}
+
+		root, err := filepath.Abs(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if !recursive {
+			add(root)
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !d.IsDir() {
+				return nil
+			}
+
+			if p != root {
+				name := d.Name()
+				if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+					name == "testdata" || name == "vendor" {
+					return filepath.SkipDir
+				}
+			}
+
+			add(p)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dirs, nil
+}
+
+func hasConfig(dir string) bool {
+	for _, name := range configFileNames {
+		if fi, err := os.Stat(filepath.Join(dir, name)); err == nil && !fi.IsDir() {
+			return true
+		}
+	}
+	return false
+}
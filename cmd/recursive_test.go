@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findConfigDirs(t *testing.T) {
+	assert := require.New(t)
+
+	root := t.TempDir()
+	for _, p := range []string{
+		"a/.mockcompose.yaml",
+		"a/b/.mockcompose.yml",
+		"c/file.go",
+		"testdata/.mockcompose.yaml",
+		".hidden/.mockcompose.yaml",
+	} {
+		assert.NoError(os.MkdirAll(filepath.Join(root, filepath.Dir(p)), 0755))
+		assert.NoError(os.WriteFile(filepath.Join(root, p), []byte{}, 0644))
+	}
+
+	dirs, err := findConfigDirs([]string{root + "/..."})
+	assert.NoError(err)
+	assert.Equal([]string{filepath.Join(root, "a"), filepath.Join(root, "a", "b")}, dirs)
+
+	dirs, err = findConfigDirs([]string{filepath.Join(root, "a"), filepath.Join(root, "c")})
+	assert.NoError(err)
+	assert.Equal([]string{filepath.Join(root, "a")}, dirs)
+}
+
+func Test_executeConfigInDir(t *testing.T) {
+	assert := require.New(t)
+
+	cwd, err := os.Getwd()
+	assert.NoError(err)
+
+	dir, err := filepath.Abs("../test/yaml")
+	assert.NoError(err)
+
+	memory := newMemorySink()
+	summary := executeConfigInDir(dir, 2, memory, nil, newSelection(CommandOptions{}, map[string]bool{}, []string{"mockFoo"}))
+	assert.NoError(summary.err)
+	assert.Equal([]string{"mockc_mockFoo_test.go"}, summary.outputs)
+
+	// entries are executed in dir without changing working directory
+	wd, err := os.Getwd()
+	assert.NoError(err)
+	assert.Equal(cwd, wd)
+	assert.Equal([]string{filepath.Join(dir, "mockc_mockFoo_test.go")}, memory.paths())
+}
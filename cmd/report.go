@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
+)
+
+// runReport is the machine-readable report of a run, see -report
+type runReport struct {
+	Mode     string         `json:"mode"` // write, check, diff or stdout
+	ExitCode int            `json:"exitCode"`
+	Entries  []*entryReport `json:"entries"`
+
+	// generated files that are out of date or missing in check mode
+	Stale   []string `json:"stale,omitempty"`
+	Missing []string `json:"missing,omitempty"`
+
+	baseDir string // working directory of the run
+}
+
+// entryReport reports execution of a configuration entry
+type entryReport struct {
+	Dir         string               `json:"dir"` // working directory of the entry
+	Options     CommandOptions       `json:"options"`
+	Files       []string             `json:"files"`
+	Summary     *mockcompose.Summary `json:"summary,omitempty"`
+	Diagnostics []diagnosticReport   `json:"diagnostics,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+type diagnosticReport struct {
+	Level   string `json:"level"` // warn or error
+	Kind    string `json:"kind,omitempty"`
+	Message string `json:"message"`
+	Pos     string `json:"pos,omitempty"` // file:line:column of source code
+}
+
+func newRunReport(mode string, baseDir string) *runReport {
+	return &runReport{
+		Mode:    mode,
+		Entries: []*entryReport{},
+		baseDir: baseDir,
+	}
+}
+
+// addEntry reports an entry executed in current working directory, it does
+// nothing if no report is requested. Entries are added one by one in entry order
+func (r *runReport) addEntry(options *CommandOptions, files []mockcompose.GeneratedFile, err error) {
+	if r == nil {
+		return
+	}
+	r.Entries = append(r.Entries, newEntryReport(options, r.baseDir, files, err))
+}
+
+// write writes report as indented JSON document
+func (r *runReport) write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// newEntryReport reports an entry executed in its Dir with generated files and
+// error of the execution, paths are relative to baseDir
+func newEntryReport(options *CommandOptions, baseDir string, files []mockcompose.GeneratedFile, err error) *entryReport {
+	dir, _ := filepath.Abs(options.Dir)
+
+	report := &entryReport{
+		Dir:     relPath(baseDir, dir),
+		Options: *options,
+		Files:   []string{},
+	}
+
+	for _, file := range files {
+		path, _ := filepath.Abs(file.Path)
+		report.Files = append(report.Files, relPath(baseDir, path))
+
+		summary := file.Summary
+		report.Summary = &summary
+	}
+
+	var diagnostics []mockcompose.Diagnostic
+	var genErr *mockcompose.Error
+	if errors.As(err, &genErr) {
+		diagnostics = genErr.Diagnostics
+	} else if len(files) > 0 {
+		diagnostics = files[0].Diagnostics
+	}
+
+	for _, d := range diagnostics {
+		level := "warn"
+		if d.Level == logger.ERROR {
+			level = "error"
+		}
+
+		kind := ""
+		if d.Kind != 0 {
+			kind = d.Kind.String()
+		}
+
+		report.Diagnostics = append(report.Diagnostics, diagnosticReport{
+			Level:   level,
+			Kind:    kind,
+			Message: d.Message,
+			Pos:     d.Pos,
+		})
+	}
+
+	if err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// relPath returns path relative to baseDir if possible
+func relPath(baseDir, path string) string {
+	if rel, err := filepath.Rel(baseDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
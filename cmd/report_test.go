@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
+)
+
+func Test_runReport(t *testing.T) {
+	assert := require.New(t)
+
+	cwd, err := os.Getwd()
+	assert.NoError(err)
+
+	report := newRunReport("write", filepath.Dir(cwd))
+
+	report.addEntry(&CommandOptions{MockName: "mockFoo", MockPkg: "foo"}, []mockcompose.GeneratedFile{
+		{
+			Path: "mockc_mockFoo_test.go",
+			Diagnostics: []mockcompose.Diagnostic{
+				{Level: logger.WARN, Message: "override ignored"},
+			},
+			Summary: mockcompose.Summary{Mocked: []string{"Bar"}},
+		},
+	}, nil)
+	report.addEntry(&CommandOptions{MockName: "mockBar"}, nil, usageError("no source to generate against"))
+	report.ExitCode = exitCode(usageError(""))
+
+	var b bytes.Buffer
+	assert.NoError(report.write(&b))
+
+	doc := map[string]interface{}{}
+	assert.NoError(json.Unmarshal(b.Bytes(), &doc))
+	assert.Equal("write", doc["mode"])
+	assert.Equal(float64(exitUsage), doc["exitCode"])
+
+	entries := doc["entries"].([]interface{})
+	assert.Len(entries, 2)
+
+	entry := entries[0].(map[string]interface{})
+	assert.Equal("cmd", entry["dir"])
+	assert.Equal("mockFoo", entry["options"].(map[string]interface{})["name"])
+	assert.Equal([]interface{}{"cmd/mockc_mockFoo_test.go"}, entry["files"])
+	assert.Equal(map[string]interface{}{"mocked": []interface{}{"Bar"}}, entry["summary"])
+	assert.Equal([]interface{}{
+		map[string]interface{}{"level": "warn", "message": "override ignored"},
+	}, entry["diagnostics"])
+	assert.Nil(entry["error"])
+
+	entry = entries[1].(map[string]interface{})
+	assert.Equal([]interface{}{}, entry["files"])
+	assert.Equal([]interface{}{
+		map[string]interface{}{"level": "error", "kind": "usage error", "message": "no source to generate against"},
+	}, entry["diagnostics"])
+	assert.Equal("no source to generate against", entry["error"])
+}
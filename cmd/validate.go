@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/kelveny/mockcompose/pkg/goload"
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
+)
+
+// executeValidate executes validate subcommand, which strictly checks YAML
+// configurations in files or directories of args against source code without
+// generating code, problems are logged with line and column
+func executeValidate(args []string) error {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	vb := flags.Bool("v", false, "if set, print verbose logging messages")
+	quiet := flags.Bool("q", false, "if set, print errors only")
+	logFormat := flags.String("log-format", "text", "format of logging messages, text or kv for key=value pairs")
+	flags.Usage = func() {
+		logger.Log(logger.PROMPT, `Usage: %s validate [options] [configuration files or directories]
+
+Validate checks YAML configurations in files or directories, directories may be
+in format of go tool package patterns, i.e., ./..., current working directory
+is checked if none is given.
+`, os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := configureLogger(*vb, *quiet, *logFormat); err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return err
+	}
+
+	files, err := findConfigFiles(flags.Args())
+	if err != nil {
+		logger.Log(logger.ERROR, "%s\n", err)
+		return usageError(err.Error())
+	}
+
+	errs := []error{}
+	for _, file := range files {
+		if err := validateConfigFile(file, cwd); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// findConfigFiles returns absolute paths of configuration files of args, which
+// are configuration files or directory patterns
+func findConfigFiles(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	files := []string{}
+	for _, arg := range args {
+		if fi, err := os.Stat(arg); err == nil && !fi.IsDir() {
+			path, err := filepath.Abs(arg)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, path)
+			continue
+		}
+
+		dirs, err := findConfigDirs([]string{arg})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(dirs) == 0 {
+			return nil, fmt.Errorf("no mockcompose YAML configuration found in %s", arg)
+		}
+
+		for _, dir := range dirs {
+			for _, name := range configFileNames {
+				if fi, err := os.Stat(filepath.Join(dir, name)); err == nil && !fi.IsDir() {
+					files = append(files, filepath.Join(dir, name))
+					break
+				}
+			}
+		}
+	}
+	return files, nil
+}
+
+// configValidator records problems of a configuration file at their positions
+type configValidator struct {
+	name        string // name of the configuration file in messages
+	diagnostics []mockcompose.Diagnostic
+}
+
+func (v *configValidator) fail(kind mockcompose.Kind, node *yaml.Node, format string, args ...interface{}) {
+	pos := v.name
+	if node != nil {
+		pos = fmt.Sprintf("%s:%d:%d", v.name, node.Line, node.Column)
+	}
+
+	v.diagnostics = append(v.diagnostics, mockcompose.Diagnostic{
+		Level:   logger.ERROR,
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+		Pos:     pos,
+	})
+}
+
+// configEntry is a configuration entry with its YAML node
+type configEntry struct {
+	node   *yaml.Node
+	fields map[string]*yaml.Node // key -> value
+	keys   map[string]*yaml.Node // key -> key
+	broken bool                  // structure of the entry is invalid
+}
+
+// validateConfigFile validates configuration file, names in messages are relative to cwd
+func validateConfigFile(file string, cwd string) error {
+	v := &configValidator{name: relPath(cwd, file)}
+	logger.Log(logger.VERBOSE, "Validate YAML configuration %s\n", v.name)
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logger.Log(logger.ERROR, "Error in reading file %s, error: %s\n", v.name, err)
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		v.fail(mockcompose.UsageError, nil, "%s", strings.TrimPrefix(err.Error(), "yaml: "))
+		if line := yamlErrorLine(err); line > 0 {
+			v.diagnostics[0].Pos = fmt.Sprintf("%s:%d", v.name, line)
+		}
+		return v.report()
+	}
+
+	defaults, entries := v.checkStructure(&doc)
+	if defaults != nil && defaults.broken {
+		// options of entries are unknown without defaults
+		return v.report()
+	}
+
+	// check entries that are well formed with defaults applied against source
+	cfg, checked, err := loadConfigEntries(file, defaults, entries)
+	if err != nil {
+		v.fail(mockcompose.UsageError, nil, "unable to load configuration: %s", err)
+		return v.report()
+	}
+
+	outputs := make(map[string]int)
+	for i := range cfg.Mockcompose {
+		options := &cfg.Mockcompose[i]
+		entry := entries[checked[i]]
+
+		// entries are checked in directory of the configuration
+		options.Dir = filepath.Dir(file)
+
+		entryOptions := *options
+		entryOptions.Loader = goload.Default
+		err := mockcompose.Validate(context.Background(), entryOptions)
+
+		var genErr *mockcompose.Error
+		if errors.As(err, &genErr) {
+			for _, d := range genErr.Diagnostics {
+				v.diagnostics = append(v.diagnostics, mockcompose.Diagnostic{
+					Level:   d.Level,
+					Kind:    d.Kind,
+					Message: fmt.Sprintf("entry %s: %s", options.MockName, d.Message),
+					Pos:     v.optionPos(entry, defaults, d.Option),
+				})
+			}
+		} else if err != nil {
+			return err
+		}
+
+		name := relPath(options.Dir, options.OutputFileName())
+		if first, ok := outputs[name]; ok {
+			v.fail(mockcompose.UsageError, entry.fields["name"], "entry %s: %s is also generated by entry #%d %s",
+				options.MockName, name, checked[first]+1, cfg.Mockcompose[first].MockName)
+		} else {
+			outputs[name] = i
+		}
+	}
+
+	return v.report()
+}
+
+// loadConfigEntries loads configuration of file with entries that are well formed,
+// defaults of the configuration and its ancestors are applied. It returns indexes
+// of the loaded entries in entries as well
+func loadConfigEntries(file string, defaults *configEntry, entries []*configEntry) (*Config, []int, error) {
+	cfg := &Config{}
+	if defaults != nil {
+		if err := defaults.node.Decode(&cfg.Defaults); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	checked := []int{}
+	for i, entry := range entries {
+		if entry.broken {
+			continue
+		}
+
+		var options CommandOptions
+		if err := entry.node.Decode(&options); err != nil {
+			return nil, nil, err
+		}
+
+		keys := make(map[string]bool)
+		for key := range entry.fields {
+			keys[key] = true
+		}
+
+		cfg.Mockcompose = append(cfg.Mockcompose, options)
+		cfg.keys = append(cfg.keys, keys)
+		checked = append(checked, i)
+	}
+
+	if err := cfg.inheritDefaults(filepath.Dir(file)); err != nil {
+		return nil, nil, err
+	}
+	cfg.applyDefaults()
+	return cfg, checked, nil
+}
+
+// checkStructure strictly checks keys and values of configuration, it returns
+// defaults and entries of configuration
+func (v *configValidator) checkStructure(doc *yaml.Node) (*configEntry, []*configEntry) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		v.fail(mockcompose.UsageError, nil, "configuration is empty")
+		return nil, nil
+	}
+
+	root := v.checkMapping(doc.Content[0], "configuration", reflect.TypeOf(Config{}))
+	if root == nil {
+		return nil, nil
+	}
+
+	var defaults *configEntry
+	if node, ok := root.fields["defaults"]; ok {
+		defaults = v.checkMapping(node, "defaults", reflect.TypeOf(Defaults{}))
+	}
+
+	entries := []*configEntry{}
+	if node, ok := root.fields["mockcompose"]; ok {
+		if node.Kind != yaml.SequenceNode {
+			v.fail(mockcompose.UsageError, node, "mockcompose must be a list of entries")
+			return defaults, nil
+		}
+
+		for i, item := range node.Content {
+			entry := v.checkMapping(item, fmt.Sprintf("entry #%d", i+1), reflect.TypeOf(CommandOptions{}))
+			if entry == nil {
+				entry = &configEntry{node: item, broken: true}
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return defaults, entries
+}
+
+// checkMapping checks that node is a mapping of which keys are yaml fields of
+// typ with values of the field types
+func (v *configValidator) checkMapping(node *yaml.Node, what string, typ reflect.Type) *configEntry {
+	if node.Kind != yaml.MappingNode {
+		v.fail(mockcompose.UsageError, node, "%s must be a mapping", what)
+		return nil
+	}
+
+	fields := yamlFields(typ)
+
+	entry := &configEntry{
+		node:   node,
+		fields: make(map[string]*yaml.Node),
+		keys:   make(map[string]*yaml.Node),
+	}
+	defer func(problems int) {
+		entry.broken = len(v.diagnostics) > problems
+	}(len(v.diagnostics))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		fieldType, ok := fields[key.Value]
+		if !ok {
+			v.fail(mockcompose.UsageError, key, "unknown field %q in %s%s", key.Value, what, suggestField(key.Value, fields))
+			continue
+		}
+
+		if _, ok := entry.fields[key.Value]; ok {
+			v.fail(mockcompose.UsageError, key, "duplicate field %q in %s", key.Value, what)
+			continue
+		}
+		entry.fields[key.Value] = value
+		entry.keys[key.Value] = key
+
+		if fieldType.Kind() == reflect.Struct || fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct {
+			// nested structures are checked by callers
+			continue
+		}
+
+		if err := value.Decode(reflect.New(fieldType).Interface()); err != nil {
+			v.fail(mockcompose.UsageError, value, "invalid value of %s, %s is expected", key.Value, describeType(fieldType))
+		}
+	}
+	return entry
+}
+
+// optionPos returns position of option of entry in format of key or key[index],
+// options that are not set by entry are taken from defaults
+func (v *configValidator) optionPos(entry *configEntry, defaults *configEntry, option string) string {
+	key, index := option, -1
+	if i := strings.Index(option, "["); i > 0 && strings.HasSuffix(option, "]") {
+		key = option[:i]
+		index, _ = strconv.Atoi(option[i+1 : len(option)-1])
+	}
+
+	node := entry.node
+	if value, ok := entry.fields[key]; ok {
+		node = entry.keys[key]
+		if index >= 0 && value.Kind == yaml.SequenceNode && index < len(value.Content) {
+			node = value.Content[index]
+		}
+	} else if defaults != nil && defaults.keys[key] != nil {
+		node = defaults.keys[key]
+	}
+	return fmt.Sprintf("%s:%d:%d", v.name, node.Line, node.Column)
+}
+
+// report logs problems of configuration and returns them as an error
+func (v *configValidator) report() error {
+	if len(v.diagnostics) == 0 {
+		logger.Log(logger.PROMPT, "%s is valid\n", v.name)
+		return nil
+	}
+
+	for _, d := range v.diagnostics {
+		logger.Default.LogAt(d.Level, d.Pos, "%s\n", d.Message)
+	}
+	return &mockcompose.Error{Diagnostics: v.diagnostics}
+}
+
+// yamlFields returns types of fields of struct typ by their yaml names
+func yamlFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func describeType(typ reflect.Type) string {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Slice:
+		return "a list of " + typ.Elem().Kind().String() + "s"
+	}
+	return "a " + typ.Kind().String()
+}
+
+// suggestField suggests field that name is likely a typo of, fields are checked
+// in order so that the same field is suggested every time
+func suggestField(name string, fields map[string]reflect.Type) string {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	for _, field := range names {
+		if strings.EqualFold(field, name) || strings.EqualFold(field+"s", name) || strings.EqualFold(field, name+"s") {
+			return fmt.Sprintf(", did you mean %q?", field)
+		}
+	}
+	return ""
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// yamlErrorLine returns line of YAML syntax error, or 0 if it is unknown
+func yamlErrorLine(err error) int {
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+	return 0
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/kelveny/mockcompose/pkg/mockcompose"
+)
+
+func Test_validateConfigFile(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".mockcompose.yaml")
+	assert.NoError(os.WriteFile(file, []byte(`defaults:
+  testonly: true
+mockcompose:
+  - name: mockFoo
+    classname: foo
+    real: "Foo,fmt"
+  - name: mockBar
+    expecter: maybe
+    name: mockBaz
+`), 0644))
+
+	err := validateConfigFile(file, dir)
+
+	var genErr *mockcompose.Error
+	assert.True(errors.As(err, &genErr))
+	assert.Equal(mockcompose.UsageError, genErr.Kind())
+
+	messages := []string{}
+	for _, d := range genErr.Diagnostics {
+		messages = append(messages, d.Pos+": "+d.Message)
+	}
+	assert.Equal([]string{
+		`.mockcompose.yaml:2:3: unknown field "testonly" in defaults, did you mean "testOnly"?`,
+		`.mockcompose.yaml:5:5: unknown field "classname" in entry #1, did you mean "className"?`,
+		`.mockcompose.yaml:6:11: invalid value of real, a list of strings is expected`,
+		`.mockcompose.yaml:8:15: invalid value of expecter, true or false is expected`,
+		`.mockcompose.yaml:9:5: duplicate field "name" in entry #2`,
+	}, messages)
+
+	assert.NoError(os.WriteFile(file, []byte("mockcompose:\n  - name: [x\n"), 0644))
+	err = validateConfigFile(file, dir)
+	assert.True(errors.As(err, &genErr))
+	assert.Equal(".mockcompose.yaml:2", genErr.Diagnostics[0].Pos)
+}
+
+func Test_validateConfigFile_source(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".mockcompose.yaml")
+	assert.NoError(os.WriteFile(file, []byte(`mockcompose:
+  - name: mockFoo
+    expecter: maybe
+  - name: mockFmt
+    sourcePkg: fmt
+    mock:
+      - Sprintf
+      - Nope
+`), 0644))
+
+	err := validateConfigFile(file, dir)
+
+	// well formed entries are checked against source even if others are broken
+	var genErr *mockcompose.Error
+	assert.True(errors.As(err, &genErr))
+	positions := []string{}
+	for _, d := range genErr.Diagnostics {
+		positions = append(positions, d.Pos)
+	}
+	assert.Equal([]string{".mockcompose.yaml:3:15", ".mockcompose.yaml:8:9"}, positions)
+	assert.Equal(mockcompose.UsageError, genErr.Kind())
+}
+
+func Test_validateConfigFile_decoding(t *testing.T) {
+	assert := require.New(t)
+
+	content := []byte(`mockcompose:
+  - name: mockFoo
+    interfaceName: Foo
+    testOnly: maybe
+`)
+
+	// configuration that validate rejects is not loaded for generation either
+	var cfg Config
+	assert.Error(yaml.Unmarshal(content, &cfg))
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".mockcompose.yaml")
+	assert.NoError(os.WriteFile(file, content, 0644))
+
+	var genErr *mockcompose.Error
+	assert.True(errors.As(validateConfigFile(file, dir), &genErr))
+	assert.Equal(".mockcompose.yaml:4:15", genErr.Diagnostics[0].Pos)
+}
+
+func Test_suggestField(t *testing.T) {
+	assert := require.New(t)
+
+	fields := map[string]reflect.Type{
+		"test":  reflect.TypeOf(""),
+		"Tests": reflect.TypeOf(""),
+		"other": reflect.TypeOf(""),
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(`, did you mean "Tests"?`, suggestField("tests", fields))
+	}
+	assert.Equal("", suggestField("unknown", fields))
+}
@@ -1,21 +1,28 @@
 package gofile
 
 import (
+	"fmt"
 	"go/format"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/kelveny/mockcompose/pkg/logger"
 	"golang.org/x/tools/imports"
 )
 
+// DerivePackage derives package name from current working directory, it returns
+// empty string if current working directory is not accessible
 func DerivePackage(anchor bool) string {
-	path, err := filepath.Abs("")
+	return DeriveDirPackage("", anchor)
+}
+
+// DeriveDirPackage derives package name from dir, empty dir refers to current
+// working directory
+func DeriveDirPackage(dir string, anchor bool) string {
+	path, err := filepath.Abs(dir)
 	if err != nil {
-		logger.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
-		os.Exit(1)
+		return ""
 	}
 
 	if path, ok := StripGopath(path); ok {
@@ -33,8 +40,6 @@ func StripGopath(p string) (string, bool) {
 	gopathConfig := GetGoPathConfig()
 
 	for _, gopath := range strings.Split(gopathConfig, string(filepath.ListSeparator)) {
-		logger.Log(logger.VERBOSE, "check gopath: %s\n", gopath)
-
 		if strings.HasPrefix(p, gopath+string(filepath.Separator)) {
 			p = strings.Replace(p, gopath+string(filepath.Separator), "", 1)
 
@@ -78,25 +83,33 @@ func GetGoPathConfig() string {
 	return gopathConfig
 }
 
-func FormatGoFile(filePath string) {
+// FormatGoFile formats Go source file in place
+func FormatGoFile(filePath string) error {
 	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("Error in reading file %s, error: %s", filePath, err)
+	}
 
+	bb, err := FormatGoSource(filePath, b)
 	if err != nil {
-		logger.Log(logger.ERROR, "Error in reading file %s, error: %s\n", filePath, err)
-		return
+		return err
 	}
 
-	bb, err := format.Source(b)
+	return ioutil.WriteFile(filePath, bb, 0644)
+}
+
+// FormatGoSource formats Go source that is to be saved as filePath, imports are
+// resolved in the directory of filePath
+func FormatGoSource(filePath string, src []byte) ([]byte, error) {
+	b, err := format.Source(src)
 	if err != nil {
-		logger.Log(logger.ERROR, "Error in formatting Go source %s, error: %s\n", filePath, err)
-		return
+		return nil, fmt.Errorf("Error in formatting Go source %s, error: %s", filePath, err)
 	}
 
-	bb, err = imports.Process(filePath, bb, nil)
+	b, err = imports.Process(filePath, b, nil)
 	if err != nil {
-		logger.Log(logger.ERROR, "Error in formatting Go imports %s, error: %s\n", filePath, err)
-		return
+		return nil, fmt.Errorf("Error in formatting Go imports %s, error: %s", filePath, err)
 	}
 
-	ioutil.WriteFile(filePath, bb, 0644)
+	return b, nil
 }
@@ -0,0 +1,191 @@
+package gogen
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+const DefaultBackend = "testify"
+
+// MockClz describes a mocking class, TypeParamsDecl and TypeParamNames are in
+// format of [K comparable, V any] and [K, V] respectively for generic mocking
+// class, and empty otherwise
+type MockClz struct {
+	Name           string
+	TypeParamsDecl string
+	TypeParamNames string
+
+	// composite class embeds source class and auto-generated package mocking classes
+	Composite bool
+	SrcField  string
+	SrcType   string
+	SrcLocked bool // source class contains locks, constructors do not take it by value
+	PkgMocks  []*MockClz
+
+	// spy class delegates calls to a real implementation of the interface type
+	// unless expectations are set up, i.e., Store[K, V]
+	Delegate string
+
+	// type parameter names of class declaration if TypeParamNames are renamed
+	declTypeParamNames string
+}
+
+// TypeDecl returns type name used in type declaration, i.e., fooMock[K comparable, V any]
+func (c *MockClz) TypeDecl() string {
+	return c.Name + c.TypeParamsDecl
+}
+
+// TypeName returns type name used in method receivers, i.e., fooMock[K, V]
+func (c *MockClz) TypeName() string {
+	return c.Name + c.TypeParamNames
+}
+
+// WithTypeParamNames returns a copy of the class that refers to type parameters
+// with different names, i.e., from receiver of a source method
+func (c *MockClz) WithTypeParamNames(typeParamNames string) *MockClz {
+	clz := *c
+	clz.declTypeParamNames = c.declParamNames()
+	clz.TypeParamNames = typeParamNames
+	return &clz
+}
+
+// declParamNames returns type parameter names of class declaration, i.e., [K, V]
+func (c *MockClz) declParamNames() string {
+	if c.declTypeParamNames != "" {
+		return c.declTypeParamNames
+	}
+	return c.TypeParamNames
+}
+
+// renameTypeParams returns copies of infos with type parameter identifiers in
+// their types renamed by position, from [A, B] to [K, V] for example
+func renameTypeParams(infos []*gosyntax.FieldDeclInfo, from, to string) []*gosyntax.FieldDeclInfo {
+	names := map[string]string{}
+	toNames := typeParamNameList(to)
+	for i, name := range typeParamNameList(from) {
+		if name != "_" && i < len(toNames) {
+			names[name] = toNames[i]
+		}
+	}
+
+	renamed := make([]*gosyntax.FieldDeclInfo, 0, len(infos))
+	for _, info := range infos {
+		copied := *info
+		copied.Typ = renameIdents(info.Typ, names)
+		renamed = append(renamed, &copied)
+	}
+	return renamed
+}
+
+func typeParamNameList(typeParamNames string) []string {
+	names := strings.Split(strings.Trim(typeParamNames, "[]"), ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return names
+}
+
+// renameIdents renames identifiers in type expression typ, selectors of
+// qualified identifiers, i.e., B in pkg.B, are left untouched
+func renameIdents(typ string, names map[string]string) string {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(typ))
+	s.Init(file, []byte(typ), nil, 0)
+
+	var sb strings.Builder
+	last, prev := 0, token.ILLEGAL
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if to, ok := names[lit]; ok && tok == token.IDENT && prev != token.PERIOD {
+			offset := file.Offset(pos)
+			sb.WriteString(typ[last:offset])
+			sb.WriteString(to)
+			last = offset + len(lit)
+		}
+		prev = tok
+	}
+	sb.WriteString(typ[last:])
+	return sb.String()
+}
+
+// Backend renders generated code for a mocking framework
+type Backend interface {
+	// Imports returns imports that generated code always depends on
+	Imports() []gosyntax.ImportSpec
+
+	// WriteClzDecl writes type declaration of mocking class, together with
+	// its auto-generated package mocking classes
+	WriteClzDecl(writer io.Writer, clz *MockClz)
+
+	// WriteClzHelpers writes per-mock helpers of mocking class and its
+	// auto-generated package mocking classes, i.e., constructor and EXPECT()
+	WriteClzHelpers(writer io.Writer, clz *MockClz)
+
+	// WriteFuncMock writes mocking method on mocking class, paramInfos are
+	// resolved from type signature and named
+	WriteFuncMock(
+		writer io.Writer,
+		clz *MockClz,
+		fnName string,
+		paramInfos []*gosyntax.FieldDeclInfo,
+		returnInfos []*gosyntax.FieldDeclInfo,
+	)
+}
+
+// BackendOptions are options that backends may support
+type BackendOptions struct {
+	Expecter bool // generate typed EXPECT() API
+	Spy      bool // generate spy classes, see MockClz.Delegate
+}
+
+var backendFactories = map[string]func(options BackendOptions) Backend{
+	"testify": func(options BackendOptions) Backend {
+		return &testifyBackend{expecter: options.Expecter, spy: options.Spy}
+	},
+	"gomock": func(options BackendOptions) Backend {
+		return &gomockBackend{}
+	},
+	"fake": func(options BackendOptions) Backend {
+		return &fakeBackend{}
+	},
+}
+
+// RegisterBackend registers a backend factory under name, it replaces the
+// existing one registered under the same name
+func RegisterBackend(name string, factory func(options BackendOptions) Backend) {
+	backendFactories[name] = factory
+}
+
+// NewBackend creates a backend registered under name, DefaultBackend is used
+// if name is empty
+func NewBackend(name string, options BackendOptions) (Backend, error) {
+	if name == "" {
+		name = DefaultBackend
+	}
+
+	if factory, ok := backendFactories[name]; ok {
+		return factory(options), nil
+	}
+
+	return nil, fmt.Errorf("unsupported backend %s, use one of %s", name, strings.Join(BackendNames(), ", "))
+}
+
+// BackendNames returns names of registered backends in sorted order
+func BackendNames() []string {
+	names := []string{}
+	for name := range backendFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/stretchr/testify/require"
+)
+
+type echoBackend struct{}
+
+func (b *echoBackend) Imports() []gosyntax.ImportSpec { return nil }
+
+func (b *echoBackend) WriteClzDecl(writer io.Writer, clz *MockClz) {
+	fmt.Fprintf(writer, "type %s struct{}\n", clz.TypeDecl())
+}
+
+func (b *echoBackend) WriteClzHelpers(writer io.Writer, clz *MockClz) {}
+
+func (b *echoBackend) WriteFuncMock(
+	writer io.Writer,
+	clz *MockClz,
+	fnName string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	fmt.Fprintf(writer, "func (m *%s) %s(%s) {}\n",
+		clz.TypeName(), fnName, gosyntax.ParamInfoListDeclString(paramInfos))
+}
+
+func TestNewBackend(t *testing.T) {
+	assert := require.New(t)
+
+	b, err := NewBackend("", BackendOptions{})
+	assert.NoError(err)
+	assert.IsType(&testifyBackend{}, b)
+
+	b, err = NewBackend("gomock", BackendOptions{})
+	assert.NoError(err)
+	assert.IsType(&gomockBackend{}, b)
+
+	b, err = NewBackend("fake", BackendOptions{})
+	assert.NoError(err)
+	assert.IsType(&fakeBackend{}, b)
+
+	_, err = NewBackend("unknown", BackendOptions{})
+	assert.EqualError(err, "unsupported backend unknown, use one of fake, gomock, testify")
+}
+
+func TestRegisterBackend(t *testing.T) {
+	assert := require.New(t)
+
+	RegisterBackend("echo", func(options BackendOptions) Backend {
+		return &echoBackend{}
+	})
+	defer delete(backendFactories, "echo")
+
+	b, err := NewBackend("echo", BackendOptions{})
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	clz := &MockClz{Name: "fooMock", TypeParamsDecl: "[T any]", TypeParamNames: "[T]"}
+	b.WriteClzDecl(&buf, clz)
+	GenerateFuncMock(&buf, b, nil, clz, "Get", []*gosyntax.FieldDeclInfo{{Typ: "T"}}, nil, nil)
+
+	assert.Equal("type fooMock[T any] struct{}\nfunc (m *fooMock[T]) Get(_a0 T) {}\n", buf.String())
+}
+
+func TestConstructorOfLockedSourceClass(t *testing.T) {
+	assert := require.New(t)
+
+	for _, name := range []string{"testify", "gomock", "fake"} {
+		b, err := NewBackend(name, BackendOptions{})
+		assert.NoError(err)
+
+		clz := &MockClz{Name: "raceMock", Composite: true, SrcField: "race", SrcType: "race"}
+
+		var buf bytes.Buffer
+		b.WriteClzHelpers(&buf, clz)
+		assert.Contains(buf.String(), "src race", name)
+
+		clz.SrcLocked = true
+		buf.Reset()
+		b.WriteClzHelpers(&buf, clz)
+		assert.NotContains(buf.String(), "src", name)
+	}
+}
@@ -0,0 +1,188 @@
+package gogen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+const (
+	expecterDeclTemplate = `
+type {{ .Name }}_Expecter{{ .TypeParamsDecl }} struct {
+	{{- if .Delegate }}
+	mock *{{ .Name }}{{ .TypeParamNames }}
+	{{- else }}
+	mock *mock.Mock
+	{{- end }}
+}
+
+func (m *{{ .Name }}{{ .TypeParamNames }}) EXPECT() *{{ .Name }}_Expecter{{ .TypeParamNames }} {
+	{{- if .Delegate }}
+	return &{{ .Name }}_Expecter{{ .TypeParamNames }}{mock: m}
+	{{- else }}
+	return &{{ .Name }}_Expecter{{ .TypeParamNames }}{mock: &m.Mock}
+	{{- end }}
+}
+`
+
+	funcExpecterTemplate = `
+type {{ .CallClz }}{{ .TypeParamsDecl }} struct {
+	*mock.Call
+}
+
+func (_e *{{ .Name }}_Expecter{{ .TypeParamNames }}) {{ .FnName }}({{ .MatcherParamsDecl }}) *{{ .CallClz }}{{ .TypeParamNames }} {
+	return &{{ .CallClz }}{{ .TypeParamNames }}{Call: _e.mock.On("{{ .FnName }}", {{ .MatcherArgsExpr }})}
+}
+
+func (_c *{{ .CallClz }}{{ .TypeParamNames }}) Run(run func({{ .ParamsDecl }})) *{{ .CallClz }}{{ .TypeParamNames }} {
+	_c.Call.Run(func(_args mock.Arguments) {
+	{{- range $index, $p := .Params }}
+	{{- if $p.Variadic }}
+		_a{{ $index }} := make([]{{ $p.Typ }}, len(_args)-{{ $index }})
+		for _i, _a := range _args[{{ $index }}:] {
+			if _a != nil {
+				_a{{ $index }}[_i] = _a.({{ $p.Typ }})
+			}
+		}
+	{{- else }}
+		var _a{{ $index }} {{ $p.Typ }}
+		if _args[{{ $index }}] != nil {
+			_a{{ $index }} = _args[{{ $index }}].({{ $p.Typ }})
+		}
+	{{- end }}
+	{{- end }}
+		run({{ .RunArgsExpr }})
+	})
+	return _c
+}
+
+func (_c *{{ .CallClz }}{{ .TypeParamNames }}) Return({{ .ReturnsDecl }}) *{{ .CallClz }}{{ .TypeParamNames }} {
+	_c.Call.Return({{ .ReturnArgsExpr }})
+	return _c
+}
+
+func (_c *{{ .CallClz }}{{ .TypeParamNames }}) RunAndReturn(run func({{ .ParamTypesDecl }}) {{ .ReturnTypesDecl }}) *{{ .CallClz }}{{ .TypeParamNames }} {
+	{{- if .Returns }}
+	_c.Call.Return(run)
+	return _c
+	{{- else }}
+	return _c.Run(run)
+	{{- end }}
+}
+`
+)
+
+type expecterParam struct {
+	Typ      string
+	Variadic bool
+}
+
+type funcExpecterBinding struct {
+	*MockClz
+
+	CallClz string
+	FnName  string
+
+	Params  []expecterParam
+	Returns []*gosyntax.FieldDeclInfo
+
+	MatcherParamsDecl string
+	MatcherArgsExpr   string
+	ParamsDecl        string
+	ParamTypesDecl    string
+	RunArgsExpr       string
+	ReturnsDecl       string
+	ReturnArgsExpr    string
+	ReturnTypesDecl   string
+}
+
+// writeExpecterDecl generates expecter class and EXPECT() method of mocking class
+func writeExpecterDecl(writer io.Writer, clz *MockClz) {
+	t := template.Must(template.New("MockComposeExpecter").Parse(expecterDeclTemplate))
+	t.Execute(writer, clz)
+}
+
+// writeFuncExpecter generates typed call class of a mocked function, together with
+// the expecter method that sets up the call, paramInfos are expected to be fixed up
+func writeFuncExpecter(
+	writer io.Writer,
+	clz *MockClz,
+	fnName string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	binding := &funcExpecterBinding{
+		MockClz: clz,
+		CallClz: fmt.Sprintf("%s_%s_Call", clz.Name, fnName),
+		FnName:  fnName,
+		Returns: returnInfos,
+
+		ParamsDecl:     gosyntax.ParamInfoListDeclString(paramInfos),
+		ParamTypesDecl: gosyntax.ParamInfoListTypeOnlyDeclString(paramInfos),
+	}
+
+	runArgs := []string{}
+	for index, p := range paramInfos {
+		typ := strings.TrimPrefix(p.Typ, "...")
+		binding.Params = append(binding.Params, expecterParam{Typ: typ, Variadic: p.Variadic})
+
+		if p.Variadic {
+			runArgs = append(runArgs, fmt.Sprintf("_a%d...", index))
+		} else {
+			runArgs = append(runArgs, fmt.Sprintf("_a%d", index))
+		}
+	}
+	binding.RunArgsExpr = strings.Join(runArgs, ", ")
+	binding.MatcherParamsDecl, binding.MatcherArgsExpr = matcherParams(paramInfos)
+
+	returns := []string{}
+	returnArgs := []string{}
+	returnTypes := []string{}
+	for index, r := range returnInfos {
+		returns = append(returns, fmt.Sprintf("_r%d %s", index, r.Typ))
+		returnArgs = append(returnArgs, fmt.Sprintf("_r%d", index))
+		returnTypes = append(returnTypes, r.Typ)
+	}
+	binding.ReturnsDecl = strings.Join(returns, ", ")
+	binding.ReturnArgsExpr = strings.Join(returnArgs, ", ")
+	if len(returnTypes) > 1 {
+		binding.ReturnTypesDecl = fmt.Sprintf("(%s)", strings.Join(returnTypes, ", "))
+	} else {
+		binding.ReturnTypesDecl = strings.Join(returnTypes, "")
+	}
+
+	t := template.Must(template.New("MockComposeFuncExpecter").Parse(funcExpecterTemplate))
+	t.Execute(writer, binding)
+}
+
+// matcherParams returns parameter declaration and argument expression of a method
+// that accepts matchers, with every parameter declared as interface{}
+func matcherParams(paramInfos []*gosyntax.FieldDeclInfo) (paramsDecl string, argsExpr string) {
+	params := []string{}
+	args := []string{}
+	for _, p := range paramInfos {
+		if p.Variadic {
+			params = append(params, p.Name+" ...interface{}")
+		} else {
+			params = append(params, p.Name+" interface{}")
+			args = append(args, p.Name)
+		}
+	}
+	paramsDecl = strings.Join(params, ", ")
+
+	// mock.On() and gomock.Controller.RecordCallWithMethodType() accept ...interface{},
+	// for variadic parameters, append them to the fixed ones
+	argsExpr = strings.Join(args, ", ")
+	if len(paramInfos) > 0 && paramInfos[len(paramInfos)-1].Variadic {
+		variadicName := paramInfos[len(paramInfos)-1].Name
+		if len(args) > 0 {
+			argsExpr = fmt.Sprintf("append([]interface{}{%s}, %s...)...", argsExpr, variadicName)
+		} else {
+			argsExpr = variadicName + "..."
+		}
+	}
+	return
+}
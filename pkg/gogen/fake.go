@@ -0,0 +1,243 @@
+package gogen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+const (
+	fakeFuncTemplate = `
+type {{ .CallClz }}{{ .TypeParamsDecl }} struct {
+	{{- range .CallFields }}
+	{{ .Name }} {{ .Typ }}
+	{{- end }}
+}
+
+func (m *{{ .MockClz }}) {{ .FnName }}({{ .ParamsDecl }}) {{ .ReturnsDecl }} {
+	if m.{{ .FnName }}Func == nil{{ if .Delegate }} && m.delegate == nil{{ end }} {
+		panic("{{ .MockName }}.{{ .FnName }}Func: method is nil but {{ .FnName }} was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.{{ .FnName }} = append(m.mockcCalls.{{ .FnName }}, {{ .CallClz }}{{ .TypeParamNames }}{
+	{{- range .CallFields }}
+		{{ .Name }}: {{ .ParamName }},
+	{{- end }}
+	})
+	m.mockcLock.Unlock()
+	{{- if .Delegate }}
+
+	if m.{{ .FnName }}Func == nil {
+		{{ if .Returns }}return {{ end }}m.delegate.{{ .FnName }}({{ .InvokeParamsExpr }})
+		{{- if not .Returns }}
+		return
+		{{- end }}
+	}
+	{{- end }}
+
+	{{ if .Returns }}return {{ end }}m.{{ .FnName }}Func({{ .InvokeParamsExpr }})
+}
+
+func (m *{{ .MockClz }}) {{ .FnName }}Calls() []{{ .CallClz }}{{ .TypeParamNames }} {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]{{ .CallClz }}{{ .TypeParamNames }}, len(m.mockcCalls.{{ .FnName }}))
+	copy(calls, m.mockcCalls.{{ .FnName }})
+	return calls
+}
+`
+
+	fakeConstructorTemplate = `func %s%s(%s %s) *%s {
+	return &%s{%s: %s}
+}
+
+`
+)
+
+// fakeBackend generates dependency-free moq (https://github.com/matryer/moq) style
+// fakes, every mocked method is backed by a XxxFunc field and calls are recorded
+// in a mutex protected call log that is accessible through XxxCalls()
+type fakeBackend struct {
+	// mocking class name -> mocked methods in order of generation
+	methods map[string][]*fakeMethod
+}
+
+type fakeMethod struct {
+	fnName      string
+	callClz     string
+	paramInfos  []*gosyntax.FieldDeclInfo
+	returnInfos []*gosyntax.FieldDeclInfo
+}
+
+type fakeCallField struct {
+	Name      string
+	Typ       string
+	ParamName string
+}
+
+type fakeFuncBinding struct {
+	MockName       string
+	MockClz        string
+	TypeParamsDecl string
+	TypeParamNames string
+	Delegate       string
+	CallClz        string
+	FnName         string
+
+	ParamsDecl       string
+	ReturnsDecl      string
+	InvokeParamsExpr string
+	Returns          []*gosyntax.FieldDeclInfo
+
+	CallFields []fakeCallField
+}
+
+func (b *fakeBackend) Imports() []gosyntax.ImportSpec {
+	return []gosyntax.ImportSpec{
+		{
+			Name: "sync",
+			Path: "sync",
+		},
+	}
+}
+
+func (b *fakeBackend) WriteClzDecl(writer io.Writer, clz *MockClz) {
+	if clz.Composite {
+		fmt.Fprintf(writer, compositeClzTemplateBegin, clz.TypeDecl(), clz.SrcType, b.clzFields(clz))
+		for _, pkgMock := range clz.PkgMocks {
+			fmt.Fprintf(writer, "	%s\n", pkgMock.TypeName())
+		}
+		fmt.Fprint(writer, compositeClzTemplateEnd)
+	} else if clz.Delegate != "" {
+		fmt.Fprintf(writer, mockClzTemplate, clz.TypeDecl(), b.clzFields(clz)+"\n\n\tdelegate "+clz.Delegate)
+	} else {
+		fmt.Fprintf(writer, mockClzTemplate, clz.TypeDecl(), b.clzFields(clz))
+	}
+
+	for _, pkgMock := range clz.PkgMocks {
+		fmt.Fprintf(writer, mockClzTemplate, pkgMock.TypeDecl(), b.clzFields(pkgMock))
+	}
+}
+
+// clzFields returns XxxFunc fields, call log and lock of mocking class
+func (b *fakeBackend) clzFields(clz *MockClz) string {
+	lines := []string{}
+	for _, method := range b.methods[clz.Name] {
+		lines = append(lines, fmt.Sprintf("%sFunc func(%s) %s",
+			method.fnName,
+			gosyntax.ParamInfoListDeclString(method.paramInfos),
+			gosyntax.ReturnInfoListDeclString(method.returnInfos),
+		))
+	}
+
+	lines = append(lines, "", "mockcCalls struct {")
+	for _, method := range b.methods[clz.Name] {
+		lines = append(lines, fmt.Sprintf("\t%s []%s%s", method.fnName, method.callClz, clz.TypeParamNames))
+	}
+	lines = append(lines, "}", "mockcLock sync.Mutex")
+
+	return strings.Join(lines, "\n\t")
+}
+
+func (b *fakeBackend) WriteClzHelpers(writer io.Writer, clz *MockClz) {
+	// fakes are ready to use as zero values, constructor is only needed to embed
+	// source class that can be copied or to take the real implementation that spy
+	// class delegates to
+	if clz.SrcField != "" && !clz.SrcLocked {
+		fmt.Fprintf(writer, fakeConstructorTemplate,
+			constructorName(clz.Name),
+			clz.TypeParamsDecl,
+			"src",
+			clz.SrcType,
+			clz.TypeName(),
+			clz.TypeName(),
+			clz.SrcField,
+			"src",
+		)
+	} else if clz.Delegate != "" {
+		fmt.Fprintf(writer, fakeConstructorTemplate,
+			constructorName(clz.Name),
+			clz.TypeParamsDecl,
+			"delegate",
+			clz.Delegate,
+			clz.TypeName(),
+			clz.TypeName(),
+			"delegate",
+			"delegate",
+		)
+	}
+}
+
+func (b *fakeBackend) WriteFuncMock(
+	writer io.Writer,
+	clz *MockClz,
+	fnName string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	// fakes do not clone source method bodies, render peer methods with type
+	// parameter names of class declaration that XxxFunc fields and call
+	// structs are declared with
+	if declNames := clz.declParamNames(); declNames != clz.TypeParamNames {
+		paramInfos = renameTypeParams(paramInfos, clz.TypeParamNames, declNames)
+		returnInfos = renameTypeParams(returnInfos, clz.TypeParamNames, declNames)
+		clz = clz.WithTypeParamNames(declNames)
+	}
+
+	method := &fakeMethod{
+		fnName:      fnName,
+		callClz:     clz.Name + exportedName(fnName) + "Call",
+		paramInfos:  paramInfos,
+		returnInfos: returnInfos,
+	}
+	if b.methods == nil {
+		b.methods = make(map[string][]*fakeMethod)
+	}
+	b.methods[clz.Name] = append(b.methods[clz.Name], method)
+
+	binding := &fakeFuncBinding{
+		MockName:       clz.Name,
+		MockClz:        clz.TypeName(),
+		TypeParamsDecl: clz.TypeParamsDecl,
+		TypeParamNames: clz.TypeParamNames,
+		Delegate:       clz.Delegate,
+		CallClz:        method.callClz,
+		FnName:         fnName,
+
+		ParamsDecl:       gosyntax.ParamInfoListDeclString(paramInfos),
+		ReturnsDecl:      gosyntax.ReturnInfoListDeclString(returnInfos),
+		InvokeParamsExpr: gosyntax.ParamInfoListInvokeString(paramInfos),
+		Returns:          returnInfos,
+	}
+
+	for _, p := range paramInfos {
+		typ := p.Typ
+		if p.Variadic {
+			typ = "[]" + strings.TrimPrefix(typ, "...")
+		}
+		binding.CallFields = append(binding.CallFields, fakeCallField{
+			Name:      exportedName(p.Name),
+			Typ:       typ,
+			ParamName: p.Name,
+		})
+	}
+
+	t := template.Must(template.New("MockComposeFake").Parse(fakeFuncTemplate))
+	t.Execute(writer, binding)
+}
+
+// exportedName returns name with leading underscores trimmed and first letter
+// capitalized, i.e., _a0 becomes A0
+func exportedName(name string) string {
+	runes := []rune(strings.TrimLeft(name, "_"))
+	if len(runes) == 0 {
+		return name
+	}
+	return string(unicode.ToUpper(runes[0])) + string(runes[1:])
+}
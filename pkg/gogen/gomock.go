@@ -0,0 +1,216 @@
+package gogen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+const (
+	gomockImportPath = "go.uber.org/mock/gomock"
+
+	gomockClzFieldsTemplate = `ctrl     *gomock.Controller
+	recorder *%s`
+
+	gomockRecorderTemplate = `type %s struct {
+	mock *%s
+}
+
+func (m *%s) EXPECT() *%s {
+	return m.recorder
+}
+
+`
+	gomockConstructorTemplateBegin = `func %s%s(ctrl *gomock.Controller%s) *%s {
+	m := &%s{ctrl: ctrl%s}
+	m.recorder = &%s{mock: m}
+`
+	gomockConstructorTemplateEnd = `
+	return m
+}
+
+`
+
+	gomockFuncTemplate = `
+func (m *{{ .MockClz }}) {{ .FnName }}({{ .ParamsDecl }}) {{ .ReturnsDecl }} {
+	m.ctrl.T.Helper()
+	{{- if .Variadic }}
+
+	_mc_args := []interface{}{ {{- .FixedArgsExpr -}} }
+	for _, _va := range {{ .VariadicName }} {
+		_mc_args = append(_mc_args, _va)
+	}
+	{{ if .Returns }}_mc_ret := {{ end }}m.ctrl.Call(m, "{{ .FnName }}", _mc_args...)
+	{{- else }}
+	{{ if .Returns }}_mc_ret := {{ end }}m.ctrl.Call(m, "{{ .FnName }}", {{ .FixedArgsExpr }})
+	{{- end }}
+	{{- range $index, $r := .Returns }}
+	_r{{ $index }}, _ := _mc_ret[{{ $index }}].({{ $r.Typ }})
+	{{- end }}
+	{{- if .Returns }}
+	return {{ .ReturnArgsExpr }}
+	{{- end }}
+}
+
+func (mr *{{ .RecorderClz }}) {{ .FnName }}({{ .MatcherParamsDecl }}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "{{ .FnName }}", reflect.TypeOf((*{{ .MockClz }})(nil).{{ .FnName }}), {{ .MatcherArgsExpr }})
+}
+`
+)
+
+type gomockFuncBinding struct {
+	MockClz     string
+	RecorderClz string
+	FnName      string
+
+	ParamsDecl  string
+	ReturnsDecl string
+
+	Variadic      bool
+	VariadicName  string
+	FixedArgsExpr string
+
+	Returns        []*gosyntax.FieldDeclInfo
+	ReturnArgsExpr string
+
+	MatcherParamsDecl string
+	MatcherArgsExpr   string
+}
+
+// gomockBackend generates gomock (https://github.com/uber-go/mock) compatible mocking
+// implementation, mocking classes are driven by *gomock.Controller
+type gomockBackend struct{}
+
+func (b *gomockBackend) Imports() []gosyntax.ImportSpec {
+	return []gosyntax.ImportSpec{
+		{
+			Name: "gomock",
+			Path: gomockImportPath,
+		},
+		{
+			Name: "reflect",
+			Path: "reflect",
+		},
+	}
+}
+
+func (b *gomockBackend) WriteClzDecl(writer io.Writer, clz *MockClz) {
+	if clz.Composite {
+		fmt.Fprintf(writer, compositeClzTemplateBegin, clz.TypeDecl(), clz.SrcType,
+			fmt.Sprintf(gomockClzFieldsTemplate, gomockRecorderClzName(clz.TypeName())))
+		for _, pkgMock := range clz.PkgMocks {
+			fmt.Fprintf(writer, "	%s\n", pkgMock.TypeName())
+		}
+		fmt.Fprint(writer, compositeClzTemplateEnd)
+	} else {
+		fmt.Fprintf(writer, mockClzTemplate, clz.TypeDecl(),
+			fmt.Sprintf(gomockClzFieldsTemplate, gomockRecorderClzName(clz.TypeName())))
+	}
+
+	for _, pkgMock := range clz.PkgMocks {
+		fmt.Fprintf(writer, mockClzTemplate, pkgMock.TypeDecl(),
+			fmt.Sprintf(gomockClzFieldsTemplate, gomockRecorderClzName(pkgMock.TypeName())))
+	}
+}
+
+func (b *gomockBackend) WriteClzHelpers(writer io.Writer, clz *MockClz) {
+	b.writeRecorder(writer, clz)
+	for _, pkgMock := range clz.PkgMocks {
+		b.writeRecorder(writer, pkgMock)
+	}
+
+	b.writeConstructor(writer, clz)
+}
+
+// writeRecorder generates recorder class and EXPECT() method of mocking class
+func (b *gomockBackend) writeRecorder(writer io.Writer, clz *MockClz) {
+	fmt.Fprintf(writer, gomockRecorderTemplate,
+		gomockRecorderClzName(clz.TypeDecl()),
+		clz.TypeName(),
+		clz.TypeName(),
+		gomockRecorderClzName(clz.TypeName()),
+	)
+}
+
+// writeConstructor generates constructor of mocking class that binds the mock and
+// its embedded package mocks to the controller, for composite class with source
+// class, constructor accepts a source class value to embed unless the source
+// class contains locks
+func (b *gomockBackend) writeConstructor(writer io.Writer, clz *MockClz) {
+	var srcParam, srcInit string
+	if clz.SrcField != "" && !clz.SrcLocked {
+		srcParam = ", src " + clz.SrcType
+		srcInit = ", " + clz.SrcField + ": src"
+	}
+
+	fmt.Fprintf(writer, gomockConstructorTemplateBegin,
+		constructorName(clz.Name),
+		clz.TypeParamsDecl,
+		srcParam,
+		clz.TypeName(),
+		clz.TypeName(),
+		srcInit,
+		gomockRecorderClzName(clz.TypeName()),
+	)
+
+	for _, pkgMock := range clz.PkgMocks {
+		fmt.Fprintf(writer, "\tm.%s.ctrl = ctrl\n", pkgMock.Name)
+		fmt.Fprintf(writer, "\tm.%s.recorder = &%s{mock: &m.%s}\n",
+			pkgMock.Name, gomockRecorderClzName(pkgMock.TypeName()), pkgMock.Name)
+	}
+
+	fmt.Fprint(writer, gomockConstructorTemplateEnd)
+}
+
+func (b *gomockBackend) WriteFuncMock(
+	writer io.Writer,
+	clz *MockClz,
+	fnName string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	binding := &gomockFuncBinding{
+		MockClz:     clz.TypeName(),
+		RecorderClz: gomockRecorderClzName(clz.TypeName()),
+		FnName:      fnName,
+		ParamsDecl:  gosyntax.ParamInfoListDeclString(paramInfos),
+		ReturnsDecl: gosyntax.ReturnInfoListDeclString(returnInfos),
+		Returns:     returnInfos,
+	}
+
+	fixedArgs := []string{}
+	for _, p := range paramInfos {
+		if p.Variadic {
+			binding.Variadic = true
+			binding.VariadicName = p.Name
+		} else {
+			fixedArgs = append(fixedArgs, p.Name)
+		}
+	}
+	binding.FixedArgsExpr = strings.Join(fixedArgs, ", ")
+
+	returnArgs := []string{}
+	for index := range returnInfos {
+		returnArgs = append(returnArgs, fmt.Sprintf("_r%d", index))
+	}
+	binding.ReturnArgsExpr = strings.Join(returnArgs, ", ")
+
+	binding.MatcherParamsDecl, binding.MatcherArgsExpr = matcherParams(paramInfos)
+
+	t := template.Must(template.New("MockComposeGomock").Parse(gomockFuncTemplate))
+	t.Execute(writer, binding)
+}
+
+// gomockRecorderClzName returns name of the recorder class of a gomock mocking class,
+// type parameters of generic mocking class are kept, i.e., fooMock[K, V] becomes
+// fooMockMockRecorder[K, V]
+func gomockRecorderClzName(mockClz string) string {
+	if i := strings.Index(mockClz, "["); i >= 0 {
+		return mockClz[:i] + "MockRecorder" + mockClz[i:]
+	}
+	return mockClz + "MockRecorder"
+}
@@ -10,7 +10,6 @@ import (
 	"io"
 	"sort"
 	"strings"
-	"text/template"
 
 	"github.com/kelveny/mockcompose/pkg/gosyntax"
 	"github.com/kelveny/mockcompose/pkg/gotype"
@@ -18,28 +17,6 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
-const (
-	returnFieldTemplate = ` 
-	_mc_ret := {{ .MockCallExpr }}
-	{{ range $index, $f := .Fields }}
-	var _r{{ $index }} {{ $f.Typ }}
-
-	if _rfn, ok := _mc_ret.Get({{ $index }}).({{ $f.TypeFuncDecl }}); ok {
-		_r{{ $index }} = _rfn({{ $.FuncInvokeParamsExpr }})
-	} else {	
-	{{- if isErrorType $f }}
-		_r{{ $index }} = _mc_ret.Error({{ $index }})
-	{{- else }}
-		if _mc_ret.Get({{ $index }}) != nil {
-			_r{{ $index }} = _mc_ret.Get({{ $index }}).({{ $f.Typ }})
-		}
-	{{- end }}
-	}
-	{{ end }}
-	return {{ join . }}
-`
-)
-
 func GetPackageImports(pkg *packages.Package) []gosyntax.ImportSpec {
 	var specs []gosyntax.ImportSpec
 
@@ -120,6 +97,8 @@ func WriteImportDecls(writer io.Writer, imports []gosyntax.ImportSpec) {
 	fmt.Fprintln(writer)
 }
 
+// WriteFuncDecls writes function declarations, together with type declarations
+// that come along with them (i.e., expecter classes), imports are skipped
 func WriteFuncDecls(
 	writer io.Writer,
 	fset *token.FileSet,
@@ -127,9 +106,15 @@ func WriteFuncDecls(
 ) {
 	if len(file.Decls) > 0 {
 		for _, d := range file.Decls {
-			if fnSpec, ok := d.(*ast.FuncDecl); ok {
-				format.Node(writer, fset, fnSpec)
+			switch decl := d.(type) {
+			case *ast.FuncDecl:
+				format.Node(writer, fset, decl)
 				writer.Write([]byte("\n\n"))
+			case *ast.GenDecl:
+				if decl.Tok == token.TYPE {
+					format.Node(writer, fset, decl)
+					writer.Write([]byte("\n\n"))
+				}
 			}
 		}
 	}
@@ -205,88 +190,46 @@ func generateLocalOverrides(writer io.Writer, overrides map[string]string) {
 	}
 }
 
-// generate m.Called() expression (calling into testify/mock.Called() method)
-func generateMockDotCalledExpr(
-	paramInfos []*gosyntax.FieldDeclInfo,
-) (string, string) {
-
-	if len(paramInfos) == 0 {
-		return "m.Called()", ""
-	}
-
-	lastParam := paramInfos[len(paramInfos)-1]
-	if !lastParam.Variadic {
-		return fmt.Sprintf("m.Called(%s)", gosyntax.ParamInfoListInvokeString(paramInfos)), ""
-	}
-
-	if lastParam.Typ == "...interface{}" && len(paramInfos) == 1 {
-		return fmt.Sprintf("m.Called(%s...)", lastParam.Name), ""
-	}
-
-	// testify/mock.Called() accepts ...interface{}, for variadic parameters,
-	// just convert it to slice
-	lines := []string{}
-	lines = append(lines, fmt.Sprintf(`
-	_mc_args := make([]interface{}, 0, %d+len(%s))
-	`, len(paramInfos)-1, lastParam.Name))
-
-	for i := 0; i < len(paramInfos)-1; i++ {
-		lines = append(lines, fmt.Sprintf(`
-	_mc_args = append(_mc_args, %s)
-	`, paramInfos[i].Name))
-	}
-
-	lines = append(lines, fmt.Sprintf(`
-	for _, _va := range %s {
-		_mc_args = append(_mc_args, _va)
-	}
-	`, lastParam.Name))
-
-	setupBlock := strings.Join(lines, "")
-	return "m.Called(_mc_args...)", setupBlock
-}
-
-type ReturnFieldBindingSpec struct {
-	Name         string
-	Typ          string
-	TypeFuncDecl string
-}
-
-type ReturnFieldBinding struct {
-	FuncInvokeParamsExpr string
-	MockCallExpr         string
-	Fields               []ReturnFieldBindingSpec
-}
-
-func buildReturnFieldBinding(
+// resolveFuncInfos overrides syntax based param and return infos with the ones
+// inferred from type signature, and names unnamed parameters
+func resolveFuncInfos(
+	qualifier types.Qualifier,
 	paramInfos []*gosyntax.FieldDeclInfo,
 	returnInfos []*gosyntax.FieldDeclInfo,
-) *ReturnFieldBinding {
-	fields := []ReturnFieldBindingSpec{}
+	signature *types.Signature,
+) {
+	if signature != nil {
+		// override with inferred info from type signature, syntax based infos
+		// are kept if types of the signature can not be rendered
+		if p, err := gotype.GetQualifiedFuncParamInfos(signature, qualifier); err == nil {
+			for index, info := range p {
+				if !strings.Contains(info.Typ, "invalid type") {
+					paramInfos[index] = p[index]
+				}
+			}
+		}
 
-	for _, f := range returnInfos {
-		fields = append(fields, ReturnFieldBindingSpec{
-			Name: f.Name,
-			Typ:  f.Typ,
-			TypeFuncDecl: fmt.Sprintf("func(%s) %s",
-				gosyntax.ParamInfoListTypeOnlyDeclString(paramInfos),
-				f.Typ,
-			),
-		})
+		if p, err := gotype.GetQualifiedFuncReturnInfos(signature, qualifier); err == nil {
+			for index, info := range p {
+				if !strings.Contains(info.Typ, "invalid type") {
+					returnInfos[index] = p[index]
+				}
+			}
+		}
 	}
 
-	return &ReturnFieldBinding{
-		Fields: fields,
-	}
+	// FuncDecl of method definition from interface may come in unnamed
+	// make sure that we name these parameters before code generation
+	gosyntax.ParamInfoListFixup(paramInfos)
 }
 
-// MockFunc generates a mocking method on mockClz class
-// generate mockery (https://github.com/vektra/mockery) compatible mocking implementation
-// from syntax based declarations
+// MockFunc generates a mocking method on mocking class from syntax based declarations
+// with the backend, qualifier is used to qualify types from signature
 func MockFunc(
 	writer io.Writer,
-	mockPkg string,
-	mockClz string,
+	backend Backend,
+	qualifier types.Qualifier,
+	clz *MockClz,
 	fset *token.FileSet,
 	fnName string,
 	fnParams *ast.FieldList,
@@ -296,87 +239,22 @@ func MockFunc(
 	paramInfos := gosyntax.ParamListDeclInfo(fset, fnParams)
 	returnInfos := gosyntax.ParamListDeclInfo(fset, fnReturns)
 
-	GenerateFuncMock(writer, mockPkg, mockClz, fnName, paramInfos, returnInfos, signature)
+	GenerateFuncMock(writer, backend, qualifier, clz, fnName, paramInfos, returnInfos, signature)
 }
 
 // GenerateFuncMock generates function mock implementation based on FieldDeclInfo
-// abstraction
+// abstraction with the backend, qualifier is used to qualify types from signature
 func GenerateFuncMock(
 	writer io.Writer,
-	mockPkg string,
-	mockClz string,
+	backend Backend,
+	qualifier types.Qualifier,
+	clz *MockClz,
 	fnName string,
 	paramInfos []*gosyntax.FieldDeclInfo,
 	returnInfos []*gosyntax.FieldDeclInfo,
 	signature *types.Signature,
 ) {
-	if signature != nil {
-		// override with inferred info from type signature
-		p := gotype.GetFuncParamInfosFromSignature(signature, mockPkg)
-		for index, info := range p {
-			if !strings.Contains(info.Typ, "invalid type") {
-				paramInfos[index] = p[index]
-			}
-		}
-
-		p = gotype.GetFuncReturnInfosFromSignature(signature, mockPkg)
-		for index, info := range p {
-			if !strings.Contains(info.Typ, "invalid type") {
-				returnInfos[index] = p[index]
-			}
-		}
-	}
-
-	// FuncDecl of method definition from interface may come in unnamed
-	// make sure that we name these parameters before code generation
-	gosyntax.ParamInfoListFixup(paramInfos)
-
-	retDecl := gosyntax.ReturnInfoListDeclString(returnInfos)
-	if retDecl != "" {
-		fmt.Fprintf(
-			writer, "func (m *%s) %s(%s) %s {\n",
-			mockClz,
-			fnName,
-			gosyntax.ParamInfoListDeclString(paramInfos),
-			retDecl,
-		)
-	} else {
-		fmt.Fprintf(
-			writer, "func (m *%s) %s(%s) {\n",
-			mockClz,
-			fnName,
-			gosyntax.ParamInfoListDeclString(paramInfos),
-		)
-	}
-
-	calledExpr, calledExprSetup := generateMockDotCalledExpr(paramInfos)
-	fmt.Fprintf(writer, "%s", calledExprSetup)
-
-	if len(returnInfos) > 0 {
-		binding := buildReturnFieldBinding(paramInfos, returnInfos)
-		binding.MockCallExpr = calledExpr
-		binding.FuncInvokeParamsExpr = gosyntax.ParamInfoListInvokeString(paramInfos)
-
-		t := template.Must(template.New("MockCompose").
-			Funcs(template.FuncMap{
-				"isErrorType": func(spec ReturnFieldBindingSpec) bool {
-					return spec.Typ == "error"
-				},
-
-				"join": func(binding *ReturnFieldBinding) string {
-					s := []string{}
-					for i := range binding.Fields {
-						s = append(s, fmt.Sprintf("_r%d", i))
-					}
-
-					return strings.Join(s, ", ")
-				},
-			}).
-			Parse(returnFieldTemplate))
-		t.Execute(writer, binding)
-	} else {
-		fmt.Fprintf(writer, "\n\t%s\n", calledExpr)
-	}
+	resolveFuncInfos(qualifier, paramInfos, returnInfos, signature)
 
-	fmt.Fprintf(writer, "\n}\n")
+	backend.WriteFuncMock(writer, clz, fnName, paramInfos, returnInfos)
 }
@@ -0,0 +1,172 @@
+package gogen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+// DefaultTemplate renders generated code in the same shape as the built-in
+// output, it is a starting point for user templates
+const DefaultTemplate = `{{ .Header }}
+{{ .ImportDecls }}
+{{ .ClzDecl }}
+{{- .Helpers }}
+{{- range .Methods }}
+{{ .Code }}
+{{- end }}
+{{- range .Clones }}
+{{ . }}
+{{- end }}
+`
+
+// TemplateData is the data model passed to user templates
+type TemplateData struct {
+	Package  string                // package that generated code resides
+	MockName string                // name of the mocking class
+	Header   string                // default header comment and package clause
+	Imports  []gosyntax.ImportSpec // imports used by generated code
+	Clz      *MockClz              // mocking class, together with its package mocking classes
+
+	Methods []*TemplateMethod // mocked methods in order of generation
+	Clones  []string          // source of cloned functions and methods
+
+	// default rendering of the backend, user templates may use them as is
+	ImportDecls string
+	ClzDecl     string
+	Helpers     string
+}
+
+// TemplateMethod describes a mocked method
+type TemplateMethod struct {
+	Clz     string // name of the mocking class that method belongs to
+	Name    string
+	Params  []*gosyntax.FieldDeclInfo
+	Returns []*gosyntax.FieldDeclInfo
+	Code    string // default mocking implementation rendered by the backend
+}
+
+// TemplateBackend decorates a backend to render generated code with a user template
+type TemplateBackend struct {
+	Backend
+
+	tmpl    *template.Template
+	methods []*TemplateMethod
+	emitted map[string]bool // declarations written by the backend, see declKey
+}
+
+// NewTemplateBackend creates a backend that renders output of backend with tmpl
+func NewTemplateBackend(backend Backend, tmpl *template.Template) *TemplateBackend {
+	return &TemplateBackend{
+		Backend: backend,
+		tmpl:    tmpl,
+	}
+}
+
+// ParseTemplateFile parses a user template, templates have access to functions
+// paramsDecl, returnsDecl and invoke that render FieldDeclInfo lists
+func ParseTemplateFile(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return template.New(filepath.Base(path)).Funcs(template.FuncMap{
+		"paramsDecl":  gosyntax.ParamInfoListDeclString,
+		"returnsDecl": gosyntax.ReturnInfoListDeclString,
+		"invoke":      gosyntax.ParamInfoListInvokeString,
+	}).Parse(string(content))
+}
+
+func (b *TemplateBackend) WriteFuncMock(
+	writer io.Writer,
+	clz *MockClz,
+	fnName string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	var code bytes.Buffer
+	b.Backend.WriteFuncMock(&code, clz, fnName, paramInfos, returnInfos)
+
+	// besides the mocked method, backends may write supporting declarations, i.e.,
+	// expecter call classes, all of them are part of Code and not clones
+	if b.emitted == nil {
+		b.emitted = make(map[string]bool)
+	}
+	b.emitted[clz.Name+"."+fnName] = true
+	if f, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+code.String(), 0); err == nil {
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok {
+				b.emitted[declKey(fn)] = true
+			}
+		}
+	}
+
+	b.methods = append(b.methods, &TemplateMethod{
+		Clz:     clz.Name,
+		Name:    fnName,
+		Params:  paramInfos,
+		Returns: returnInfos,
+		Code:    code.String(),
+	})
+	writer.Write(code.Bytes())
+}
+
+// WriteFile renders generated code with the user template, file is the parsed
+// first pass output, declarations that are not written by the backend are taken
+// as clones
+func (b *TemplateBackend) WriteFile(
+	writer io.Writer,
+	data *TemplateData,
+	fset *token.FileSet,
+	file *ast.File,
+) error {
+	// mocked methods are collected per generated file
+	defer func() {
+		b.methods = nil
+		b.emitted = nil
+	}()
+
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok {
+			if b.emitted[declKey(fn)] {
+				continue
+			}
+
+			var clone bytes.Buffer
+			format.Node(&clone, fset, fn)
+			data.Clones = append(data.Clones, clone.String())
+		}
+	}
+	data.Methods = b.methods
+
+	var buf bytes.Buffer
+	WriteImportDecls(&buf, data.Imports)
+	data.ImportDecls = buf.String()
+
+	buf.Reset()
+	b.WriteClzDecl(&buf, data.Clz)
+	data.ClzDecl = buf.String()
+
+	buf.Reset()
+	b.WriteClzHelpers(&buf, data.Clz)
+	data.Helpers = buf.String()
+
+	return b.tmpl.Execute(writer, data)
+}
+
+// declKey identifies a function declaration, in format of Clz.Method for methods
+func declKey(fn *ast.FuncDecl) string {
+	if ident := gosyntax.ReceiverTypeIdent(fn.Recv); ident != nil {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
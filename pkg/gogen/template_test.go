@@ -0,0 +1,96 @@
+package gogen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+	"text/template"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateBackend(t *testing.T) {
+	assert := require.New(t)
+
+	tmpl := template.Must(template.New("test").Funcs(template.FuncMap{
+		"paramsDecl": gosyntax.ParamInfoListDeclString,
+	}).Parse(`// {{ .MockName }} in {{ .Package }}
+{{ range .Methods }}{{ .Clz }}.{{ .Name }}({{ paramsDecl .Params }})
+{{ end }}{{ range .Clones }}{{ . }}
+{{ end }}`))
+
+	b := NewTemplateBackend(&echoBackend{}, tmpl)
+
+	var buf bytes.Buffer
+	clz := &MockClz{Name: "fooMock"}
+	buf.WriteString("package foo\n\n")
+	GenerateFuncMock(&buf, b, nil, clz, "Get", []*gosyntax.FieldDeclInfo{{Name: "key", Typ: "string"}}, nil, nil)
+	buf.WriteString("func (m *fooMock) Real() {}\n")
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", buf.Bytes(), 0)
+	assert.NoError(err)
+
+	var out bytes.Buffer
+	err = b.WriteFile(&out, &TemplateData{Package: "foo", MockName: "fooMock", Clz: clz}, fset, f)
+	assert.NoError(err)
+	assert.Equal("// fooMock in foo\nfooMock.Get(key string)\nfunc (m *fooMock) Real() {}\n", out.String())
+}
+
+func TestDefaultTemplate(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := template.New("default").Parse(DefaultTemplate)
+	assert.NoError(err)
+}
+
+func TestDefaultTemplateWithBackends(t *testing.T) {
+	assert := require.New(t)
+
+	for _, name := range []string{"testify", "gomock", "fake"} {
+		backend, err := NewBackend(name, BackendOptions{Expecter: true})
+		assert.NoError(err)
+
+		b := NewTemplateBackend(backend, template.Must(template.New("default").Parse(DefaultTemplate)))
+
+		var buf bytes.Buffer
+		clz := &MockClz{Name: "fooMock"}
+		buf.WriteString("package foo\n\n")
+		GenerateFuncMock(&buf, b, nil, clz, "Get",
+			[]*gosyntax.FieldDeclInfo{{Name: "key", Typ: "string"}},
+			[]*gosyntax.FieldDeclInfo{{Typ: "error"}},
+			nil,
+		)
+		buf.WriteString("func (m *fooMock) Real() {}\n")
+
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "", buf.Bytes(), 0)
+		assert.NoError(err, name)
+
+		var out bytes.Buffer
+		err = b.WriteFile(&out, &TemplateData{
+			Header:  "package foo\n",
+			Imports: backend.Imports(),
+			Clz:     clz,
+		}, fset, f)
+		assert.NoError(err, name)
+
+		generated, err := parser.ParseFile(token.NewFileSet(), "", out.Bytes(), 0)
+		assert.NoError(err, name)
+
+		decls := make(map[string]int)
+		for _, d := range generated.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok {
+				decls[declKey(fn)]++
+			}
+		}
+		for key, count := range decls {
+			assert.Equal(1, count, "%s: %s is declared %d times", name, key, count)
+		}
+		assert.Equal(1, decls["fooMock.Real"], name)
+		assert.Equal(1, decls["fooMock.Get"], name)
+	}
+}
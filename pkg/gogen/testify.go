@@ -0,0 +1,408 @@
+package gogen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+const (
+	returnFieldTemplate = ` 
+	{{ if .MockCallExpr }}_mc_ret := {{ .MockCallExpr }}{{ end }}
+	{{- if .FuncTypeDecl }}
+
+	if _rfn, ok := _mc_ret.Get(0).({{ .FuncTypeDecl }}); ok {
+		return _rfn({{ .FuncInvokeParamsExpr }})
+	}
+	{{- end }}
+	{{ range $index, $f := .Fields }}
+	var _r{{ $index }} {{ $f.Typ }}
+
+	if _rfn, ok := _mc_ret.Get({{ $index }}).({{ $f.TypeFuncDecl }}); ok {
+		_r{{ $index }} = _rfn({{ $.FuncInvokeParamsExpr }})
+	} else {	
+	{{- if isErrorType $f }}
+		_r{{ $index }} = _mc_ret.Error({{ $index }})
+	{{- else }}
+		if _mc_ret.Get({{ $index }}) != nil {
+			_r{{ $index }} = _mc_ret.Get({{ $index }}).({{ $f.Typ }})
+		}
+	{{- end }}
+	}
+	{{ end }}
+	return {{ join . }}
+`
+	compositeClzTemplateBegin = `type %s struct {
+	%s
+	%s
+`
+	compositeClzTemplateEnd = `
+}
+
+`
+	mockClzTemplate = `type %s struct {
+	%s
+}
+
+`
+	constructorTemplateBegin = `func %s%s(t interface {
+	mock.TestingT
+	Cleanup(func())
+}%s) *%s {
+	m := &%s{%s}
+	m.Mock.Test(t)
+`
+	constructorTemplateEnd = `
+	t.Cleanup(func() {
+		m.AssertExpectations(t)%s
+	})
+
+	return m
+}
+
+`
+	spyTemplate = `// On sets up an expectation of method on the spy, once a method has expectations
+// its calls are matched against them, like calls of a mock, instead of being
+// delegated to the real implementation
+func (m *%[1]s) On(methodName string, arguments ...interface{}) *mock.Call {
+	m.spyExpected.Store(methodName, true)
+	return m.Mock.On(methodName, arguments...)
+}
+
+// spy calls method with args through the mock if the method has expectations,
+// otherwise the call is only recorded and delegated is true, caller then
+// delegates the call to the real implementation. Calls are matched and recorded
+// one at a time, Run functions of expectations must not wait for other calls
+// of the spy or assert on it
+func (m *%[1]s) spy(method string, args ...interface{}) (ret mock.Arguments, delegated bool) {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+
+	if _, ok := m.spyExpected.Load(method); ok || m.delegate == nil {
+		return m.MethodCalled(method, args...), false
+	}
+
+	m.Calls = append(m.Calls, mock.Call{Parent: &m.Mock, Method: method, Arguments: args})
+	return nil, true
+}
+
+// assertions of the spy wait for in-flight calls that may record delegated calls
+
+func (m *%[1]s) AssertExpectations(t mock.TestingT) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertExpectations(t)
+}
+
+func (m *%[1]s) AssertNumberOfCalls(t mock.TestingT, methodName string, expectedCalls int) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNumberOfCalls(t, methodName, expectedCalls)
+}
+
+func (m *%[1]s) AssertCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertCalled(t, methodName, arguments...)
+}
+
+func (m *%[1]s) AssertNotCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNotCalled(t, methodName, arguments...)
+}
+
+`
+)
+
+// testifyBackend generates mockery (https://github.com/vektra/mockery) compatible
+// mocking implementation on top of testify/mock
+type testifyBackend struct {
+	expecter bool // generate typed EXPECT() API
+	spy      bool // spy classes are guarded by a lock
+}
+
+func (b *testifyBackend) Imports() []gosyntax.ImportSpec {
+	imports := []gosyntax.ImportSpec{
+		{
+			Name: "mock",
+			Path: "github.com/stretchr/testify/mock",
+		},
+	}
+
+	if b.spy {
+		imports = append(imports, gosyntax.ImportSpec{
+			Name: "sync",
+			Path: "sync",
+		})
+	}
+	return imports
+}
+
+func (b *testifyBackend) WriteClzDecl(writer io.Writer, clz *MockClz) {
+	if clz.Composite {
+		fmt.Fprintf(writer, compositeClzTemplateBegin, clz.TypeDecl(), clz.SrcType, "mock.Mock")
+		for _, pkgMock := range clz.PkgMocks {
+			fmt.Fprintf(writer, "	%s\n", pkgMock.TypeName())
+		}
+		fmt.Fprint(writer, compositeClzTemplateEnd)
+	} else if clz.Delegate != "" {
+		fmt.Fprintf(writer, mockClzTemplate, clz.TypeDecl(), "mock.Mock\n\tdelegate "+clz.Delegate+"\n\tspyLock sync.Mutex\n\tspyExpected sync.Map")
+	} else {
+		fmt.Fprintf(writer, mockClzTemplate, clz.TypeDecl(), "mock.Mock")
+	}
+
+	for _, pkgMock := range clz.PkgMocks {
+		fmt.Fprintf(writer, mockClzTemplate, pkgMock.TypeDecl(), "mock.Mock")
+	}
+}
+
+func (b *testifyBackend) WriteClzHelpers(writer io.Writer, clz *MockClz) {
+	b.writeConstructor(writer, clz)
+
+	if clz.Delegate != "" {
+		fmt.Fprintf(writer, spyTemplate, clz.TypeName())
+	}
+
+	if b.expecter {
+		writeExpecterDecl(writer, clz)
+		for _, pkgMock := range clz.PkgMocks {
+			writeExpecterDecl(writer, pkgMock)
+		}
+	}
+}
+
+// writeConstructor generates constructor of mocking class that binds the mock and
+// its embedded package mocks to testing.T, for composite class with source class,
+// constructor accepts a source class value to embed unless the source class
+// contains locks, for spy class, constructor accepts the real implementation to
+// delegate to
+func (b *testifyBackend) writeConstructor(writer io.Writer, clz *MockClz) {
+	var srcParam, srcInit string
+	if clz.SrcField != "" && !clz.SrcLocked {
+		srcParam = ", src " + clz.SrcType
+		srcInit = clz.SrcField + ": src"
+	} else if clz.Delegate != "" {
+		srcParam = ", delegate " + clz.Delegate
+		srcInit = "delegate: delegate"
+	}
+
+	fmt.Fprintf(writer, constructorTemplateBegin,
+		constructorName(clz.Name),
+		clz.TypeParamsDecl,
+		srcParam,
+		clz.TypeName(),
+		clz.TypeName(),
+		srcInit,
+	)
+
+	asserts := []string{}
+	for _, pkgMock := range clz.PkgMocks {
+		fmt.Fprintf(writer, "\tm.%s.Test(t)\n", pkgMock.Name)
+		asserts = append(asserts, fmt.Sprintf("\n\t\tm.%s.AssertExpectations(t)", pkgMock.Name))
+	}
+
+	fmt.Fprintf(writer, constructorTemplateEnd, strings.Join(asserts, ""))
+}
+
+func (b *testifyBackend) WriteFuncMock(
+	writer io.Writer,
+	clz *MockClz,
+	fnName string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	retDecl := gosyntax.ReturnInfoListDeclString(returnInfos)
+	if retDecl != "" {
+		fmt.Fprintf(
+			writer, "func (m *%s) %s(%s) %s {\n",
+			clz.TypeName(),
+			fnName,
+			gosyntax.ParamInfoListDeclString(paramInfos),
+			retDecl,
+		)
+	} else {
+		fmt.Fprintf(
+			writer, "func (m *%s) %s(%s) {\n",
+			clz.TypeName(),
+			fnName,
+			gosyntax.ParamInfoListDeclString(paramInfos),
+		)
+	}
+
+	argsExpr, argsSetup := generateMockCallArgs(paramInfos)
+	fmt.Fprintf(writer, "%s", argsSetup)
+
+	calledExpr := fmt.Sprintf("m.Called(%s)", argsExpr)
+	if clz.Delegate != "" {
+		// spy calls the mock by itself
+		writeSpyDelegation(writer, fnName, argsExpr, paramInfos, returnInfos)
+		calledExpr = ""
+	}
+
+	if len(returnInfos) > 0 {
+		binding := buildReturnFieldBinding(paramInfos, returnInfos)
+		binding.MockCallExpr = calledExpr
+		binding.FuncInvokeParamsExpr = gosyntax.ParamInfoListInvokeString(paramInfos)
+		if b.expecter && len(returnInfos) > 1 {
+			binding.FuncTypeDecl = fmt.Sprintf("func(%s) (%s)",
+				gosyntax.ParamInfoListTypeOnlyDeclString(paramInfos),
+				gosyntax.ParamInfoListTypeOnlyDeclString(returnInfos),
+			)
+		}
+
+		t := template.Must(template.New("MockCompose").
+			Funcs(template.FuncMap{
+				"isErrorType": func(spec ReturnFieldBindingSpec) bool {
+					return spec.Typ == "error"
+				},
+
+				"join": func(binding *ReturnFieldBinding) string {
+					s := []string{}
+					for i := range binding.Fields {
+						s = append(s, fmt.Sprintf("_r%d", i))
+					}
+
+					return strings.Join(s, ", ")
+				},
+			}).
+			Parse(returnFieldTemplate))
+		t.Execute(writer, binding)
+	} else if calledExpr != "" {
+		fmt.Fprintf(writer, "\n\t%s\n", calledExpr)
+	}
+
+	fmt.Fprintf(writer, "\n}\n")
+
+	if b.expecter {
+		writeFuncExpecter(writer, clz, fnName, paramInfos, returnInfos)
+	}
+}
+
+// writeSpyDelegation generates the part of spy method that calls the mock through
+// spy() with argsExpr, and delegates call to the real implementation unless
+// an expectation has been set up for the method
+func writeSpyDelegation(
+	writer io.Writer,
+	fnName string,
+	argsExpr string,
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) {
+	spyExpr := fmt.Sprintf("m.spy(%q)", fnName)
+	if argsExpr != "" {
+		spyExpr = fmt.Sprintf("m.spy(%q, %s)", fnName, argsExpr)
+	}
+
+	delegateExpr := fmt.Sprintf("m.delegate.%s(%s)", fnName, gosyntax.ParamInfoListInvokeString(paramInfos))
+	if len(returnInfos) > 0 {
+		fmt.Fprintf(writer, `
+	_mc_ret, _mc_delegated := %s
+	if _mc_delegated {
+		return %s
+	}
+`, spyExpr, delegateExpr)
+	} else {
+		fmt.Fprintf(writer, `
+	if _, _mc_delegated := %s; _mc_delegated {
+		%s
+	}
+`, spyExpr, delegateExpr)
+	}
+}
+
+// generate arguments of m.Called() expression (calling into testify/mock.Called() method)
+func generateMockCallArgs(
+	paramInfos []*gosyntax.FieldDeclInfo,
+) (string, string) {
+
+	if len(paramInfos) == 0 {
+		return "", ""
+	}
+
+	lastParam := paramInfos[len(paramInfos)-1]
+	if !lastParam.Variadic {
+		return gosyntax.ParamInfoListInvokeString(paramInfos), ""
+	}
+
+	if lastParam.Typ == "...interface{}" && len(paramInfos) == 1 {
+		return lastParam.Name + "...", ""
+	}
+
+	// testify/mock.Called() accepts ...interface{}, for variadic parameters,
+	// just convert it to slice
+	lines := []string{}
+	lines = append(lines, fmt.Sprintf(`
+	_mc_args := make([]interface{}, 0, %d+len(%s))
+	`, len(paramInfos)-1, lastParam.Name))
+
+	for i := 0; i < len(paramInfos)-1; i++ {
+		lines = append(lines, fmt.Sprintf(`
+	_mc_args = append(_mc_args, %s)
+	`, paramInfos[i].Name))
+	}
+
+	lines = append(lines, fmt.Sprintf(`
+	for _, _va := range %s {
+		_mc_args = append(_mc_args, _va)
+	}
+	`, lastParam.Name))
+
+	setupBlock := strings.Join(lines, "")
+	return "_mc_args...", setupBlock
+}
+
+type ReturnFieldBindingSpec struct {
+	Name         string
+	Typ          string
+	TypeFuncDecl string
+}
+
+type ReturnFieldBinding struct {
+	FuncInvokeParamsExpr string
+	MockCallExpr         string
+	Fields               []ReturnFieldBindingSpec
+
+	// type of function that returns all results at once, set by RunAndReturn() of expecter
+	FuncTypeDecl string
+}
+
+func buildReturnFieldBinding(
+	paramInfos []*gosyntax.FieldDeclInfo,
+	returnInfos []*gosyntax.FieldDeclInfo,
+) *ReturnFieldBinding {
+	fields := []ReturnFieldBindingSpec{}
+
+	for _, f := range returnInfos {
+		fields = append(fields, ReturnFieldBindingSpec{
+			Name: f.Name,
+			Typ:  f.Typ,
+			TypeFuncDecl: fmt.Sprintf("func(%s) %s",
+				gosyntax.ParamInfoListTypeOnlyDeclString(paramInfos),
+				f.Typ,
+			),
+		})
+	}
+
+	return &ReturnFieldBinding{
+		Fields: fields,
+	}
+}
+
+// constructorName returns New<MockName> for exported mocking class, and
+// new<MockName> otherwise
+func constructorName(mockName string) string {
+	runes := []rune(mockName)
+	if len(runes) == 0 {
+		return ""
+	}
+
+	if unicode.IsUpper(runes[0]) {
+		return "New" + mockName
+	}
+	return "new" + string(unicode.ToUpper(runes[0])) + string(runes[1:])
+}
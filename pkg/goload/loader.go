@@ -0,0 +1,273 @@
+package goload
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"golang.org/x/tools/go/packages"
+)
+
+// Mode is the load mode of packages shared by mockcompose
+const Mode = packages.NeedName | packages.NeedTypes | packages.NeedSyntax
+
+// Loader loads packages with packages.Load and caches them by build config and
+// import path, so that a package is loaded once and shared across the whole run.
+//
+// Patterns are either import paths or directories relative to the directory they
+// are loaded in (i.e., "."), empty pattern refers to the directory itself. Loading
+// runs outside of the lock, concurrent loads of the same package share one
+// packages.Load call.
+type Loader struct {
+	mode       packages.LoadMode
+	buildFlags []string
+	tests      bool
+
+	mutex   sync.Mutex
+	entries map[string]*entry // cache key -> package being loaded or loaded
+	roots   map[string]string // directory -> module root
+
+	log *logger.Logger // nil if loading is not logged
+}
+
+// entry is a package in cache, done is closed once loading completes
+type entry struct {
+	done chan struct{}
+	pkg  *packages.Package
+	err  error
+}
+
+// NewLoader creates a loader that loads packages with the build config of cfg,
+// Mode is used if cfg is nil
+func NewLoader(cfg *packages.Config) *Loader {
+	l := &Loader{
+		mode:    Mode,
+		entries: make(map[string]*entry),
+		roots:   make(map[string]string),
+	}
+
+	if cfg != nil {
+		l.mode = cfg.Mode
+		l.buildFlags = cfg.BuildFlags
+		l.tests = cfg.Tests
+	}
+	return l
+}
+
+// SetLogger sets logger that loading of packages is logged with
+func (l *Loader) SetLogger(log *logger.Logger) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.log = log
+}
+
+// Default is the loader shared by gosyntax, gotype and mockcompose generators
+var Default = NewLoader(nil)
+
+// LoadPackage loads package of pattern in current working directory with the
+// default loader
+func LoadPackage(pattern string) (*packages.Package, error) {
+	return Default.LoadPackage("", pattern)
+}
+
+// Preload loads packages of patterns in current working directory with the
+// default loader in a batch
+func Preload(patterns ...string) error {
+	return Default.Preload("", patterns...)
+}
+
+// LoadPackage returns package of pattern in dir, package is loaded if it is not
+// in cache. Empty dir refers to current working directory
+func (l *Loader) LoadPackage(dir string, pattern string) (*packages.Package, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.Preload(dir, pattern); err != nil {
+		return nil, err
+	}
+
+	l.mutex.Lock()
+	e, ok := l.entries[l.cacheKey(dir, pattern)]
+	l.mutex.Unlock()
+
+	if ok {
+		<-e.done
+		if e.err != nil {
+			return nil, e.err
+		}
+		if e.pkg != nil {
+			return e.pkg, nil
+		}
+	}
+	return nil, fmt.Errorf("package %s not found", pattern)
+}
+
+// Preload loads packages of patterns in dir that are not in cache yet, import
+// paths are loaded with one packages.Load call, relative directories are loaded
+// one by one. Packages that are being loaded by others are waited for
+func (l *Loader) Preload(dir string, patterns ...string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	log := l.log
+
+	var waits []*entry
+	var importPaths []string
+	dirs := make(map[string]string) // cache key -> relative pattern
+	keys := make(map[string]string) // cache key -> import path
+	for _, pattern := range patterns {
+		key := l.cacheKey(dir, pattern)
+		if e, ok := l.entries[key]; ok {
+			waits = append(waits, e)
+			continue
+		}
+
+		l.entries[key] = &entry{done: make(chan struct{})}
+		if isRelative(pattern) {
+			dirs[key] = pattern
+		} else {
+			keys[key] = pattern
+			importPaths = append(importPaths, pattern)
+		}
+	}
+	l.mutex.Unlock()
+
+	var errs []error
+	for key, pattern := range dirs {
+		pkgs, err := l.load(log, dir, []string{pattern})
+		l.complete(dir, map[string]string{key: pattern}, pkgs, err)
+		errs = append(errs, err)
+	}
+
+	if len(importPaths) > 0 {
+		pkgs, err := l.load(log, dir, importPaths)
+		l.complete(dir, keys, pkgs, err)
+		errs = append(errs, err)
+	}
+
+	for _, e := range waits {
+		<-e.done
+		errs = append(errs, e.err)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load loads packages of patterns in dir
+func (l *Loader) load(log *logger.Logger, dir string, patterns []string) ([]*packages.Package, error) {
+	if log != nil {
+		log.Log(logger.VERBOSE, "Load packages %s\n", strings.Join(patterns, ", "))
+	}
+
+	cfg := &packages.Config{
+		Mode:       l.mode,
+		BuildFlags: l.buildFlags,
+		Tests:      l.tests,
+		Dir:        dir,
+	}
+
+	loadPatterns := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		loadPatterns[i] = pattern
+		if pattern == "" {
+			loadPatterns[i] = "."
+		}
+	}
+
+	return packages.Load(cfg, loadPatterns...)
+}
+
+// complete caches loaded packages under keys, which map cache keys to patterns,
+// and wakes up the ones waiting for them. Loaded packages are cached under their
+// import paths as well. Keys are removed from cache if loading fails, so that
+// they are loaded again next time
+func (l *Loader) complete(dir string, keys map[string]string, pkgs []*packages.Package, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for key, pattern := range keys {
+		e := l.entries[key]
+		e.err = err
+		for _, pkg := range pkgs {
+			if isRelative(pattern) || pkg.PkgPath == pattern || pkg.ID == pattern {
+				e.pkg = pkg
+				break
+			}
+		}
+
+		if err != nil {
+			delete(l.entries, key)
+		}
+		close(e.done)
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.PkgPath != "" {
+			key := l.cacheKey(dir, pkg.PkgPath)
+			if _, ok := l.entries[key]; !ok {
+				e := &entry{done: make(chan struct{}), pkg: pkg}
+				close(e.done)
+				l.entries[key] = e
+			}
+		}
+	}
+}
+
+// cacheKey returns key of pattern in dir in cache, it consists of build config,
+// and absolute directory path of relative patterns, or module root of dir and
+// import path of other patterns. It must be called with lock held
+func (l *Loader) cacheKey(dir string, pattern string) string {
+	config := fmt.Sprintf("%d|%s|%t", l.mode, strings.Join(l.buildFlags, " "), l.tests)
+	if isRelative(pattern) {
+		p := pattern
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, pattern)
+		}
+		return config + "|dir:" + p
+	}
+
+	return config + "|" + l.moduleRoot(dir) + "|" + pattern
+}
+
+// moduleRoot returns directory of the go.mod file that governs dir, or empty
+// string if dir is not in a module
+func (l *Loader) moduleRoot(dir string) string {
+	if root, ok := l.roots[dir]; ok {
+		return root
+	}
+
+	root := ""
+	for d := dir; ; d = filepath.Dir(d) {
+		if fi, err := os.Stat(filepath.Join(d, "go.mod")); err == nil && !fi.IsDir() {
+			root = d
+			break
+		}
+
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	l.roots[dir] = root
+	return root
+}
+
+func isRelative(pattern string) bool {
+	return pattern == "" || pattern == "." || pattern == ".." ||
+		strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../") ||
+		filepath.IsAbs(pattern)
+}
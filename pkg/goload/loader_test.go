@@ -0,0 +1,95 @@
+package goload
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderCache(t *testing.T) {
+	assert := require.New(t)
+
+	l := NewLoader(nil)
+
+	assert.NoError(l.Preload("", "fmt", "strings", "fmt"))
+	assert.Len(l.entries, 2)
+
+	fmt1, err := l.LoadPackage("", "fmt")
+	assert.NoError(err)
+	assert.Equal("fmt", fmt1.PkgPath)
+	assert.NotNil(fmt1.Types.Scope().Lookup("Sprintf"))
+
+	fmt2, err := l.LoadPackage("", "fmt")
+	assert.NoError(err)
+	assert.Same(fmt1, fmt2)
+	assert.Len(l.entries, 2)
+}
+
+func TestLoaderRelativeDir(t *testing.T) {
+	assert := require.New(t)
+
+	l := NewLoader(nil)
+
+	pkg, err := l.LoadPackage("", ".")
+	assert.NoError(err)
+	assert.Equal("github.com/kelveny/mockcompose/pkg/goload", pkg.PkgPath)
+
+	// package loaded from directory is shared with its import path
+	byPath, err := l.LoadPackage("", "github.com/kelveny/mockcompose/pkg/goload")
+	assert.NoError(err)
+	assert.Same(pkg, byPath)
+
+	byEmpty, err := l.LoadPackage("", "")
+	assert.NoError(err)
+	assert.Same(pkg, byEmpty)
+}
+
+func TestLoaderDir(t *testing.T) {
+	assert := require.New(t)
+
+	l := NewLoader(nil)
+
+	dir, err := filepath.Abs("../logger")
+	assert.NoError(err)
+
+	// packages are loaded in dir regardless of current working directory
+	pkg, err := l.LoadPackage(dir, ".")
+	assert.NoError(err)
+	assert.Equal("github.com/kelveny/mockcompose/pkg/logger", pkg.PkgPath)
+
+	rel, err := l.LoadPackage("", "../logger")
+	assert.NoError(err)
+	assert.Same(pkg, rel)
+
+	_, err = l.LoadPackage(filepath.Join(dir, "missing"), ".")
+	assert.Error(err)
+}
+
+func TestLoaderConcurrent(t *testing.T) {
+	assert := require.New(t)
+
+	l := NewLoader(nil)
+
+	pkgs := make([]interface{}, 8)
+	var wg sync.WaitGroup
+	for i := range pkgs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			pkg, err := l.LoadPackage("", "strings")
+			if err == nil {
+				pkgs[i] = pkg
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// concurrent loads of a package share one load
+	for _, pkg := range pkgs {
+		assert.NotNil(pkg)
+		assert.Same(pkgs[0], pkg)
+	}
+}
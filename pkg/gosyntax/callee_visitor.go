@@ -1,9 +1,12 @@
 package gosyntax
 
 import (
+	"errors"
 	"go/ast"
 	"go/types"
+	"sort"
 
+	"github.com/kelveny/mockcompose/pkg/goload"
 	"golang.org/x/exp/slices"
 	"golang.org/x/tools/go/packages"
 )
@@ -27,6 +30,18 @@ type CalleeVisitor struct {
 	// functions from imported packages
 	// map package name -> functions
 	otherPkgcallees map[string][]string
+
+	// callees that are not functions, taken out by SanitizeCallees
+	filteredCallees []FilteredCallee
+}
+
+// FilteredCallee is a function-call-like callee that is not mockable, i.e., a
+// builtin function, a type conversion or a variable of function type
+type FilteredCallee struct {
+	Caller  string `json:"caller"`  // name of the calling method or function
+	Package string `json:"package"` // package name, . for package of the caller
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
 }
 
 func NewCalleeVisitor(
@@ -84,6 +99,21 @@ func (v *CalleeVisitor) GetOtherPackageCallees() map[string][]string {
 	return v.otherPkgcallees
 }
 
+// GetFilteredCallees returns callees taken out by SanitizeCallees, in order of
+// package and callee names
+func (v *CalleeVisitor) GetFilteredCallees() []FilteredCallee {
+	return v.filteredCallees
+}
+
+func (v *CalleeVisitor) filter(pkgName, calleeName, reason string) {
+	v.filteredCallees = append(v.filteredCallees, FilteredCallee{
+		Caller:  v.name,
+		Package: pkgName,
+		Name:    calleeName,
+		Reason:  reason,
+	})
+}
+
 func (v *CalleeVisitor) isSelf(x, sel string) bool {
 	return x == v.receiver && sel == v.name
 }
@@ -135,33 +165,67 @@ func (v *CalleeVisitor) Visit(node ast.Node) ast.Visitor {
 	return v
 }
 
+// SanitizeCallees takes out callees that are not functions, packages of callees are
+// loaded with l in dir, the directory of package of the caller. Callees of packages
+// that can not be loaded are taken out as well and errors of loading are returned
 func (v *CalleeVisitor) SanitizeCallees(
+	l *goload.Loader,
+	dir string,
 	imports map[string]string,
-) {
-	cfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedSyntax}
+) error {
+	// load callee packages in one batch, they are shared through loader cache
+	patterns := []string{}
+	if len(v.thisPkgCallees) > 0 {
+		patterns = append(patterns, ".")
+	}
+	for pkgName := range v.otherPkgcallees {
+		if _, ok := imports[pkgName]; ok {
+			patterns = append(patterns, imports[pkgName])
+		}
+	}
+	// errors are reported per package below
+	_ = l.Preload(dir, patterns...)
 
+	var errs []error
 	if len(v.thisPkgCallees) > 0 {
 		filteredCallees := []string{}
 
-		pkgs, _ := packages.Load(cfg, ".")
+		pkg, err := l.LoadPackage(dir, ".")
 		for _, callee := range v.thisPkgCallees {
-			if findFuncSignature(pkgs[0], callee) != nil {
+			if err != nil {
+				v.filter(".", callee, "package can not be loaded: "+err.Error())
+			} else if findFuncSignature(pkg, callee) != nil {
 				filteredCallees = append(filteredCallees, callee)
+			} else {
+				v.filter(".", callee, nonFuncReason(pkg, callee))
 			}
 		}
+		if err != nil {
+			errs = append(errs, err)
+		}
 		v.thisPkgCallees = filteredCallees
 	}
 
 	if len(v.otherPkgcallees) > 0 {
-		for pkgName, callees := range v.otherPkgcallees {
+		// packages in order, so are errors of loading
+		pkgNames := make([]string, 0, len(v.otherPkgcallees))
+		for pkgName := range v.otherPkgcallees {
+			pkgNames = append(pkgNames, pkgName)
+		}
+		sort.Strings(pkgNames)
+
+		for _, pkgName := range pkgNames {
+			callees := v.otherPkgcallees[pkgName]
 			if _, ok := imports[pkgName]; ok {
-				pkgs, err := packages.Load(cfg, imports[pkgName])
+				pkg, err := l.LoadPackage(dir, imports[pkgName])
 				if err == nil {
 					filteredCallees := []string{}
 
 					for _, callee := range callees {
-						if findFuncSignature(pkgs[0], callee) != nil {
+						if findFuncSignature(pkg, callee) != nil {
 							filteredCallees = append(filteredCallees, callee)
+						} else {
+							v.filter(pkgName, callee, nonFuncReason(pkg, callee))
 						}
 					}
 					if len(filteredCallees) > 0 {
@@ -170,13 +234,53 @@ func (v *CalleeVisitor) SanitizeCallees(
 						delete(v.otherPkgcallees, pkgName)
 					}
 				} else {
+					for _, callee := range callees {
+						v.filter(pkgName, callee, "package can not be loaded: "+err.Error())
+					}
 					delete(v.otherPkgcallees, pkgName)
+					errs = append(errs, err)
 				}
 			} else {
+				for _, callee := range callees {
+					v.filter(pkgName, callee, "package is not imported")
+				}
 				delete(v.otherPkgcallees, pkgName)
 			}
 		}
 	}
+
+	sort.SliceStable(v.filteredCallees, func(i, j int) bool {
+		if v.filteredCallees[i].Package != v.filteredCallees[j].Package {
+			return v.filteredCallees[i].Package < v.filteredCallees[j].Package
+		}
+		return v.filteredCallees[i].Name < v.filteredCallees[j].Name
+	})
+
+	return errors.Join(errs...)
+}
+
+// nonFuncReason tells why callee that looks like a function call in package p is
+// not a function
+func nonFuncReason(p *packages.Package, callee string) string {
+	var obj types.Object
+	if p != nil && p.Types != nil {
+		obj = p.Types.Scope().Lookup(callee)
+	}
+	if obj == nil {
+		obj = types.Universe.Lookup(callee)
+	}
+
+	switch obj.(type) {
+	case *types.Builtin:
+		return "builtin function"
+	case *types.TypeName:
+		return "type conversion"
+	case *types.Var:
+		return "variable of function type"
+	case nil:
+		return "not declared in package"
+	}
+	return "not a function"
 }
 
 func findFuncSignature(p *packages.Package, fnName string) *types.Signature {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/format"
+	"go/parser"
 	"go/token"
 	"path/filepath"
 	"strings"
@@ -175,7 +176,7 @@ func ForEachFuncDeclInPackage(
 
 func ForEachInterfaceDeclInPackage(
 	p *packages.Package,
-	do func(name string, methods []*ast.Field),
+	do func(name string, typeParams *ast.FieldList, methods []*ast.Field),
 ) {
 	for _, astFile := range p.Syntax {
 		ForEachInterfaceDeclInFile(astFile, do)
@@ -193,15 +194,17 @@ func ForEachFuncDeclInFile(
 	}
 }
 
+// ForEachInterfaceDeclInFile iterates all interface declarations in a AST file,
+// typeParams is nil for non-generic interfaces
 func ForEachInterfaceDeclInFile(file *ast.File,
-	do func(name string, methods []*ast.Field),
+	do func(name string, typeParams *ast.FieldList, methods []*ast.Field),
 ) {
 	for _, d := range file.Decls {
 		if gd, ok := d.(*ast.GenDecl); ok {
 			for _, spec := range gd.Specs {
 				if tspec, ok := spec.(*ast.TypeSpec); ok {
 					if intf, ok := tspec.Type.(*ast.InterfaceType); ok {
-						do(tspec.Name.Name, intf.Methods.List)
+						do(tspec.Name.Name, tspec.TypeParams, intf.Methods.List)
 					}
 				}
 			}
@@ -253,6 +256,30 @@ func ParamInfoListTypeOnlyDeclString(paramInfos []*FieldDeclInfo) string {
 	return strings.Join(names, ", ")
 }
 
+// TypeParamListDeclString returns type parameter declaration string in format of
+// [K comparable, V any], empty string is returned if there is no type parameter
+func TypeParamListDeclString(fset *token.FileSet, fl *ast.FieldList) string {
+	if fl != nil && fl.NumFields() > 0 {
+		return fmt.Sprintf("[%s]", ParamListDeclString(fset, fl))
+	}
+	return ""
+}
+
+// TypeParamListNameString returns type parameter names in format of [K, V],
+// empty string is returned if there is no type parameter
+func TypeParamListNameString(fl *ast.FieldList) string {
+	if fl != nil && fl.NumFields() > 0 {
+		names := []string{}
+		for _, field := range fl.List {
+			for _, n := range field.Names {
+				names = append(names, n.Name)
+			}
+		}
+		return fmt.Sprintf("[%s]", strings.Join(names, ", "))
+	}
+	return ""
+}
+
 func ReceiverDeclString(fset *token.FileSet, fl *ast.FieldList) string {
 	if fl != nil && fl.NumFields() > 0 {
 		return fmt.Sprintf("(%s)", ParamListDeclString(fset, fl))
@@ -294,14 +321,98 @@ func FuncDeclString(fset *token.FileSet, fn *ast.FuncDecl) string {
 }
 
 func FuncDeclReceiverSpec(fset *token.FileSet, fn *ast.FuncDecl) *ReceiverSpec {
-	if fn.Recv != nil {
-		recvStr := ParamListDeclString(fset, fn.Recv)
-
-		tokens := strings.Split(recvStr, " ")
-		if len(tokens) == 2 {
+	if fn.Recv != nil && fn.Recv.NumFields() > 0 {
+		// receiver type of generic class is in form of *T[K, V], which contains
+		// spaces in its declarative string, use AST to separate name and type
+		field := fn.Recv.List[0]
+		if len(field.Names) == 1 {
 			return &ReceiverSpec{
-				Name:     tokens[0],
-				TypeDecl: tokens[1],
+				Name:     field.Names[0].Name,
+				TypeDecl: ExprDeclString(fset, field.Type),
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReceiverTypeIdent returns the identifier of receiver base type, it works with
+// receiver types in form of T, *T, T[P], *T[P], T[P1, P2] and *T[P1, P2]
+func ReceiverTypeIdent(recv *ast.FieldList) *ast.Ident {
+	if recv == nil || recv.NumFields() == 0 {
+		return nil
+	}
+
+	t := recv.List[0].Type
+	if expr, ok := t.(*ast.StarExpr); ok {
+		t = expr.X
+	}
+
+	switch expr := t.(type) {
+	case *ast.IndexExpr:
+		t = expr.X
+	case *ast.IndexListExpr:
+		t = expr.X
+	}
+
+	ident, _ := t.(*ast.Ident)
+	return ident
+}
+
+// SameReceiverType tells if receivers are of the same base type, and are both
+// pointers or both values. Type parameter names of generic receivers are not
+// compared, i.e., *T[K, V], *T[A, B] and *T[_, V] are of the same type
+func SameReceiverType(a, b *ast.FieldList) bool {
+	identA, identB := ReceiverTypeIdent(a), ReceiverTypeIdent(b)
+	if identA == nil || identB == nil || identA.Name != identB.Name {
+		return false
+	}
+
+	_, ptrA := a.List[0].Type.(*ast.StarExpr)
+	_, ptrB := b.List[0].Type.(*ast.StarExpr)
+	return ptrA == ptrB
+}
+
+// ReceiverTypeParamNameString returns type parameter names of a generic receiver
+// in format of [K, V], empty string is returned for non-generic receiver
+func ReceiverTypeParamNameString(recv *ast.FieldList) string {
+	if recv == nil || recv.NumFields() == 0 {
+		return ""
+	}
+
+	t := recv.List[0].Type
+	if expr, ok := t.(*ast.StarExpr); ok {
+		t = expr.X
+	}
+
+	var indices []ast.Expr
+	switch expr := t.(type) {
+	case *ast.IndexExpr:
+		indices = []ast.Expr{expr.Index}
+	case *ast.IndexListExpr:
+		indices = expr.Indices
+	}
+
+	if len(indices) > 0 {
+		names := []string{}
+		for _, index := range indices {
+			if ident, ok := index.(*ast.Ident); ok {
+				names = append(names, ident.Name)
+			}
+		}
+		return fmt.Sprintf("[%s]", strings.Join(names, ", "))
+	}
+	return ""
+}
+
+// FindTypeSpec finds type declaration of the given name in AST file
+func FindTypeSpec(file *ast.File, name string) *ast.TypeSpec {
+	for _, d := range file.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				if tspec, ok := spec.(*ast.TypeSpec); ok && tspec.Name.Name == name {
+					return tspec
+				}
 			}
 		}
 	}
@@ -429,24 +540,26 @@ type ReceiverSpec struct {
 
 // Find all methods of a "class"
 //
-// For methods with pointer receivers, prepend "*" to type name when passed in clzTypeDeclString
-func FindClassMethods(clzTypeDeclString string, fset *token.FileSet, f *ast.File) map[string]*ReceiverSpec {
+// For methods with pointer receivers, prepend "*" to type name when passed in clzTypeDeclString,
+// methods of generic class are found regardless of type parameter names of their receivers
+func FindClassMethods(clzTypeDeclString string, fset *token.FileSet, files ...*ast.File) map[string]*ReceiverSpec {
 	methods := make(map[string]*ReceiverSpec)
 
-	ForEachFuncDeclInFile(f, func(funcDecl *ast.FuncDecl) {
-		if funcDecl.Recv != nil {
-			recvStr := ParamListDeclString(fset, funcDecl.Recv)
-			tokens := strings.Split(recvStr, " ")
-			if len(tokens) == 2 {
-				if tokens[1] == clzTypeDeclString {
-					methods[funcDecl.Name.Name] = &ReceiverSpec{
-						Name:     tokens[0],
-						TypeDecl: tokens[1],
-					}
+	clzType, err := parser.ParseExpr(clzTypeDeclString)
+	if err != nil {
+		return methods
+	}
+	clzRecv := &ast.FieldList{List: []*ast.Field{{Type: clzType}}}
+
+	for _, f := range files {
+		ForEachFuncDeclInFile(f, func(funcDecl *ast.FuncDecl) {
+			if spec := FuncDeclReceiverSpec(fset, funcDecl); spec != nil {
+				if SameReceiverType(clzRecv, funcDecl.Recv) {
+					methods[funcDecl.Name.Name] = spec
 				}
 			}
-		}
-	})
+		})
+	}
 
 	return methods
 }
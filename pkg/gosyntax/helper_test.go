@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/kelveny/mockcompose/pkg/goload"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/packages"
 )
@@ -92,3 +93,129 @@ func TestCalleeDetection(t *testing.T) {
 
 	assert.NoError(err)
 }
+
+func TestSanitizeCallees(t *testing.T) {
+	assert := require.New(t)
+
+	imports := map[string]string{"fmt": "fmt", "strings": "strings"}
+	v := NewCalleeVisitor(imports, nil, "", "caller")
+	v.AppendThisPackageCallee("FindTypeSpec")
+	v.AppendThisPackageCallee("len")
+	v.AppendThisPackageCallee("CalleeVisitor")
+	v.AppendOtherPackageCallee("fmt", "Println")
+	v.AppendOtherPackageCallee("strings", "Builder")
+
+	assert.NoError(v.SanitizeCallees(goload.NewLoader(nil), "", imports))
+
+	assert.Equal([]string{"FindTypeSpec"}, v.GetThisPackageCallees())
+	assert.Equal(map[string][]string{"fmt": {"Println"}}, v.GetOtherPackageCallees())
+	assert.Equal([]FilteredCallee{
+		{Caller: "caller", Package: ".", Name: "CalleeVisitor", Reason: "type conversion"},
+		{Caller: "caller", Package: ".", Name: "len", Reason: "builtin function"},
+		{Caller: "caller", Package: "strings", Name: "Builder", Reason: "type conversion"},
+	}, v.GetFilteredCallees())
+}
+
+func TestGenericReceiverSpec(t *testing.T) {
+	assert := require.New(t)
+
+	src := `package generic
+
+type Cache[K comparable, V any] struct{}
+
+func (c *Cache[K, V]) Get(k K) V {
+	var v V
+	return v
+}
+
+func (c Cache[_, V]) Peek() V {
+	var v V
+	return v
+}
+
+func (*Cache[K, V]) unnamed() {}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	assert.NoError(err)
+
+	tspec := FindTypeSpec(node, "Cache")
+	assert.NotNil(tspec)
+	assert.Equal("[K comparable, V any]", TypeParamListDeclString(fset, tspec.TypeParams))
+	assert.Equal("[K, V]", TypeParamListNameString(tspec.TypeParams))
+
+	ForEachFuncDeclInFile(node, func(funcDecl *ast.FuncDecl) {
+		assert.Equal("Cache", ReceiverTypeIdent(funcDecl.Recv).Name)
+
+		switch funcDecl.Name.Name {
+		case "Get":
+			assert.Equal(&ReceiverSpec{Name: "c", TypeDecl: "*Cache[K, V]"}, FuncDeclReceiverSpec(fset, funcDecl))
+			assert.Equal("[K, V]", ReceiverTypeParamNameString(funcDecl.Recv))
+		case "Peek":
+			assert.Equal(&ReceiverSpec{Name: "c", TypeDecl: "Cache[_, V]"}, FuncDeclReceiverSpec(fset, funcDecl))
+			assert.Equal("[_, V]", ReceiverTypeParamNameString(funcDecl.Recv))
+		case "unnamed":
+			assert.Nil(FuncDeclReceiverSpec(fset, funcDecl))
+		}
+	})
+
+	methods := FindClassMethods("*Cache[K, V]", fset, node)
+	assert.Equal(1, len(methods))
+	assert.Equal("c", methods["Get"].Name)
+}
+
+func TestFindGenericClassMethods(t *testing.T) {
+	assert := require.New(t)
+
+	src := `package generic
+
+type Cache[K comparable, V any] struct{}
+
+func (c *Cache[K, V]) Get(k K) V {
+	return c.load(k)
+}
+
+func (c *Cache[A, B]) Put(k A, v B) {}
+
+func (cache *Cache[_, V]) load(any) V {
+	var v V
+	return v
+}
+
+func (c Cache[K, V]) Peek() V {
+	var v V
+	return v
+}
+
+type Other[K comparable, V any] struct{}
+
+func (o *Other[K, V]) Get(k K) V {
+	var v V
+	return v
+}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	assert.NoError(err)
+
+	// peers are found regardless of type parameter names of their receivers
+	methods := FindClassMethods("*Cache[K, V]", fset, node)
+	assert.Equal(map[string]*ReceiverSpec{
+		"Get":  {Name: "c", TypeDecl: "*Cache[K, V]"},
+		"Put":  {Name: "c", TypeDecl: "*Cache[A, B]"},
+		"load": {Name: "cache", TypeDecl: "*Cache[_, V]"},
+	}, methods)
+
+	methods = FindClassMethods("Cache[A, B]", fset, node)
+	assert.Equal(map[string]*ReceiverSpec{
+		"Peek": {Name: "c", TypeDecl: "Cache[K, V]"},
+	}, methods)
+
+	recvs := make(map[string]*ast.FieldList)
+	ForEachFuncDeclInFile(node, func(funcDecl *ast.FuncDecl) {
+		recvs[ReceiverTypeIdent(funcDecl.Recv).Name+"."+funcDecl.Name.Name] = funcDecl.Recv
+	})
+	assert.True(SameReceiverType(recvs["Cache.Get"], recvs["Cache.load"]))
+	assert.False(SameReceiverType(recvs["Cache.Get"], recvs["Cache.Peek"]))
+	assert.False(SameReceiverType(recvs["Cache.Get"], recvs["Other.Get"]))
+}
@@ -0,0 +1,15 @@
+//go:build go1.22
+
+package gotype
+
+import "go/types"
+
+// unalias returns the declared alias and the actual type of t if t is an alias,
+// aliases are materialized as *types.Alias by default since go1.23, or with
+// GODEBUG=gotypesalias=1 in go1.22. obj is nil if t is not an alias
+func unalias(t types.Type) (obj *types.TypeName, actual types.Type) {
+	if alias, ok := t.(*types.Alias); ok {
+		return alias.Obj(), types.Unalias(alias)
+	}
+	return nil, t
+}
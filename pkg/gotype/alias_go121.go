@@ -0,0 +1,10 @@
+//go:build !go1.22
+
+package gotype
+
+import "go/types"
+
+// unalias returns nil as aliases are never materialized before go1.22
+func unalias(t types.Type) (obj *types.TypeName, actual types.Type) {
+	return nil, t
+}
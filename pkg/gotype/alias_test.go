@@ -0,0 +1,48 @@
+//go:build go1.22
+
+package gotype
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderAlias(t *testing.T) {
+	assert := require.New(t)
+
+	pkg := types.NewPackage("github.com/kelveny/mockcompose/test/ids", "ids")
+	id := types.NewAlias(types.NewTypeName(0, pkg, "ID", nil), types.Typ[types.String])
+	anyAlias := types.NewAlias(types.NewTypeName(0, nil, "any", nil), types.NewInterfaceType(nil, nil))
+
+	decl, err := RenderTypeDeclString(id, false, "ids")
+	assert.NoError(err)
+	assert.Equal("ID", decl)
+
+	decl, err = RenderTypeDeclString(types.NewMap(id, anyAlias), false, "other")
+	assert.NoError(err)
+	assert.Equal("map[ids.ID]any", decl)
+
+	sig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(
+			types.NewVar(0, nil, "format", types.Typ[types.String]),
+			types.NewVar(0, nil, "args", types.NewSlice(anyAlias)),
+		),
+		types.NewTuple(types.NewVar(0, nil, "", id)),
+		true)
+
+	params, err := GetFuncParamInfosFromSignature(sig, "other")
+	assert.NoError(err)
+	assert.Equal("...any", params[1].Typ)
+
+	results, err := GetFuncReturnInfosFromSignature(sig, "other")
+	assert.NoError(err)
+	assert.Equal("ids.ID", results[0].Typ)
+
+	tparam := types.NewTypeParam(types.NewTypeName(0, pkg, "T", nil), anyAlias)
+	generic := types.NewSignatureType(nil, nil, []*types.TypeParam{tparam}, nil, nil, false)
+	decl, err = RenderTypeParamsDeclString(generic.TypeParams(), "ids")
+	assert.NoError(err)
+	assert.Equal("[T any]", decl)
+}
@@ -2,9 +2,11 @@ package gotype
 
 import (
 	"fmt"
+	"go/token"
 	"go/types"
 	"strings"
 
+	"github.com/kelveny/mockcompose/pkg/goload"
 	"github.com/kelveny/mockcompose/pkg/gosyntax"
 	"golang.org/x/tools/go/packages"
 )
@@ -52,6 +54,7 @@ import (
 //			Signature (A Signature represents a (non-builtin) function or method type)
 //			Tuple
 //			Named
+//			Alias (since go1.22, see unalias)
 type FuncTypeSpec struct {
 	Signature  *types.Signature
 	FieldInfo  []*gosyntax.FieldDeclInfo
@@ -59,25 +62,58 @@ type FuncTypeSpec struct {
 }
 
 func GetFuncTypeSpec(pkgPath, funcName string, mockPkgName string) (*FuncTypeSpec, error) {
-	cfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedSyntax}
+	return GetQualifiedFuncTypeSpec(pkgPath, funcName, PackageNameQualifier(mockPkgName))
+}
 
-	pkgs, err := packages.Load(cfg, pkgPath)
+// GetQualifiedFuncTypeSpec is the same as GetFuncTypeSpec, except that types from
+// other packages are qualified by the passed qualifier
+func GetQualifiedFuncTypeSpec(pkgPath, funcName string, qualifier types.Qualifier) (*FuncTypeSpec, error) {
+	pkg, err := goload.LoadPackage(pkgPath)
 	if err != nil {
 		return nil, err
 	}
 
-	sig := FindFuncSignature(pkgs[0], funcName)
+	return GetPackageFuncTypeSpec(pkg, funcName, qualifier)
+}
+
+// GetPackageFuncTypeSpec is the same as GetQualifiedFuncTypeSpec, except that
+// function is looked up in the loaded package
+func GetPackageFuncTypeSpec(pkg *packages.Package, funcName string, qualifier types.Qualifier) (*FuncTypeSpec, error) {
+	pkgPath := pkg.PkgPath
+
+	sig := FindFuncSignature(pkg, funcName)
 	if sig != nil {
+		fieldInfo, err := GetQualifiedFuncParamInfos(sig, qualifier)
+		if err != nil {
+			return nil, fmt.Errorf("function %s of %s: %w", funcName, pkgPath, err)
+		}
+
+		returnInfo, err := GetQualifiedFuncReturnInfos(sig, qualifier)
+		if err != nil {
+			return nil, fmt.Errorf("function %s of %s: %w", funcName, pkgPath, err)
+		}
+
 		return &FuncTypeSpec{
 			Signature:  sig,
-			FieldInfo:  GetFuncParamInfosFromSignature(sig, mockPkgName),
-			ReturnInfo: GetFuncReturnInfosFromSignature(sig, mockPkgName),
+			FieldInfo:  fieldInfo,
+			ReturnInfo: returnInfo,
 		}, nil
 	}
 
 	return nil, fmt.Errorf("function %s not found in %s", funcName, pkgPath)
 }
 
+// PackageNameQualifier qualifies types with their package names, types from package
+// main or from the package named mockPkg are not qualified
+func PackageNameQualifier(mockPkg string) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if pkg == nil || pkg.Name() == "main" || pkg.Name() == mockPkg {
+			return ""
+		}
+		return pkg.Name()
+	}
+}
+
 func FindFuncSignature(p *packages.Package, fnName string) *types.Signature {
 	if p != nil && p.Types != nil {
 		ret := p.Types.Scope().Lookup(fnName)
@@ -90,6 +126,23 @@ func FindFuncSignature(p *packages.Package, fnName string) *types.Signature {
 	return nil
 }
 
+// FindInterface returns type of the named interface, method set of the returned
+// interface includes methods promoted from embedded interfaces
+func FindInterface(p *packages.Package, intfName string) *types.Interface {
+	if p != nil && p.Types != nil {
+		ret := p.Types.Scope().Lookup(intfName)
+		if ret != nil {
+			if named, ok := ret.Type().(*types.Named); ok {
+				if intf, ok := named.Underlying().(*types.Interface); ok {
+					return intf
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func FindInterfaceMethodSignature(
 	p *packages.Package,
 	intfName, methodName string,
@@ -113,7 +166,176 @@ func FindInterfaceMethodSignature(
 	return nil
 }
 
-func GetFuncParamInfosFromSignature(fn *types.Signature, mockPkg string) []*gosyntax.FieldDeclInfo {
+// FindInterfaceTypeParams returns type parameter list of a generic interface,
+// nil is returned if the interface is not generic
+func FindInterfaceTypeParams(
+	p *packages.Package,
+	intfName string,
+) *types.TypeParamList {
+	if p != nil && p.Types != nil {
+		ret := p.Types.Scope().Lookup(intfName)
+		if ret != nil {
+			if named, ok := ret.Type().(*types.Named); ok {
+				if _, ok := named.Underlying().(*types.Interface); ok {
+					return named.TypeParams()
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindTypeParams returns type parameter list of a generic named type,
+// nil is returned if the type is not generic
+func FindTypeParams(
+	p *packages.Package,
+	typeName string,
+) *types.TypeParamList {
+	if p != nil && p.Types != nil {
+		ret := p.Types.Scope().Lookup(typeName)
+		if ret != nil {
+			if named, ok := ret.Type().(*types.Named); ok {
+				return named.TypeParams()
+			}
+		}
+	}
+
+	return nil
+}
+
+// lockerType is the method set of sync.Locker
+var lockerType = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "Lock", types.NewSignatureType(nil, nil, nil, nil, nil, false)),
+	types.NewFunc(token.NoPos, nil, "Unlock", types.NewSignatureType(nil, nil, nil, nil, nil, false)),
+}, nil).Complete()
+
+// HasLock tells if values of t contain locks that must not be copied, i.e.,
+// sync.Mutex, a lock is a type of which pointer, but not value, implements
+// sync.Locker, which is the way go vet copylocks check finds locks
+func HasLock(t types.Type) bool {
+	return hasLock(t, make(map[types.Type]bool))
+}
+
+func hasLock(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	if _, ok := t.(*types.TypeParam); ok {
+		return false
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return false
+	}
+
+	if types.Implements(types.NewPointer(t), lockerType) && !types.Implements(t, lockerType) {
+		return true
+	}
+
+	switch tt := t.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < tt.NumFields(); i++ {
+			if hasLock(tt.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	case *types.Array:
+		return hasLock(tt.Elem(), seen)
+	}
+	return false
+}
+
+// RenderTypeParamsDeclString returns type parameter declaration string in format of
+// [K comparable, V any], empty string is returned if type parameter list is empty
+func RenderTypeParamsDeclString(tparams *types.TypeParamList, mockPkg string) (string, error) {
+	return RenderQualifiedTypeParamsDeclString(tparams, PackageNameQualifier(mockPkg))
+}
+
+// RenderQualifiedTypeParamsDeclString is the same as RenderTypeParamsDeclString, except
+// that types from other packages are qualified by the passed qualifier
+func RenderQualifiedTypeParamsDeclString(tparams *types.TypeParamList, qualifier types.Qualifier) (string, error) {
+	if tparams == nil || tparams.Len() == 0 {
+		return "", nil
+	}
+
+	var decls []string
+	for i := 0; i < tparams.Len(); i++ {
+		tp := tparams.At(i)
+		constraint, err := renderConstraintDeclString(tp.Constraint(), qualifier)
+		if err != nil {
+			return "", err
+		}
+		decls = append(decls, fmt.Sprintf("%s %s", tp.Obj().Name(), constraint))
+	}
+	return "[" + strings.Join(decls, ", ") + "]", nil
+}
+
+// RenderTypeParamsNameString returns type parameter names in format of [K, V],
+// empty string is returned if type parameter list is empty
+func RenderTypeParamsNameString(tparams *types.TypeParamList) string {
+	if tparams == nil || tparams.Len() == 0 {
+		return ""
+	}
+
+	var names []string
+	for i := 0; i < tparams.Len(); i++ {
+		names = append(names, tparams.At(i).Obj().Name())
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// renderSignature renders signature without leading func keyword, in format of
+// (params) result or (params) (results)
+func renderSignature(sig *types.Signature, qualifier types.Qualifier) (string, error) {
+	var params []string
+
+	for i := 0; i < sig.Params().Len(); i++ {
+		variadic := sig.Variadic() && i == sig.Params().Len()-1
+		param, err := RenderQualifiedTypeDeclString(sig.Params().At(i).Type(), variadic, qualifier)
+		if err != nil {
+			return "", err
+		}
+		params = append(params, param)
+	}
+
+	switch sig.Results().Len() {
+	case 0:
+		return fmt.Sprintf("(%s)", strings.Join(params, ", ")), nil
+	case 1:
+		result, err := RenderQualifiedTypeDeclString(sig.Results().At(0).Type(), false, qualifier)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s) %s", strings.Join(params, ", "), result), nil
+	default:
+		results, err := RenderQualifiedTypeDeclString(sig.Results(), false, qualifier)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s) (%s)", strings.Join(params, ", "), results), nil
+	}
+}
+
+func renderConstraintDeclString(t types.Type, qualifier types.Qualifier) (string, error) {
+	// predeclared any is an alias if aliases are materialized, otherwise it shares
+	// a unique type object in universe scope, keep it as is instead of expanding
+	// it to interface{}
+	if t == types.Universe.Lookup("any").Type() {
+		return "any", nil
+	}
+
+	return RenderQualifiedTypeDeclString(t, false, qualifier)
+}
+
+func GetFuncParamInfosFromSignature(fn *types.Signature, mockPkg string) ([]*gosyntax.FieldDeclInfo, error) {
+	return GetQualifiedFuncParamInfos(fn, PackageNameQualifier(mockPkg))
+}
+
+// GetQualifiedFuncParamInfos is the same as GetFuncParamInfosFromSignature, except that
+// types from other packages are qualified by the passed qualifier
+func GetQualifiedFuncParamInfos(fn *types.Signature, qualifier types.Qualifier) ([]*gosyntax.FieldDeclInfo, error) {
 	paramInfos := []*gosyntax.FieldDeclInfo{}
 
 	tuples := fn.Params()
@@ -122,18 +344,29 @@ func GetFuncParamInfosFromSignature(fn *types.Signature, mockPkg string) []*gosy
 			v := tuples.At(i)
 
 			variadic := fn.Variadic() && i == tuples.Len()-1
+			typ, err := RenderQualifiedTypeDeclString(v.Type(), variadic, qualifier)
+			if err != nil {
+				return nil, err
+			}
+
 			paramInfos = append(paramInfos, &gosyntax.FieldDeclInfo{
 				Name:     v.Name(),
-				Typ:      RenderTypeDeclString(v.Type(), variadic, mockPkg),
+				Typ:      typ,
 				Variadic: variadic,
 			})
 		}
 	}
 
-	return paramInfos
+	return paramInfos, nil
 }
 
-func GetFuncReturnInfosFromSignature(fn *types.Signature, mockPkg string) []*gosyntax.FieldDeclInfo {
+func GetFuncReturnInfosFromSignature(fn *types.Signature, mockPkg string) ([]*gosyntax.FieldDeclInfo, error) {
+	return GetQualifiedFuncReturnInfos(fn, PackageNameQualifier(mockPkg))
+}
+
+// GetQualifiedFuncReturnInfos is the same as GetFuncReturnInfosFromSignature, except that
+// types from other packages are qualified by the passed qualifier
+func GetQualifiedFuncReturnInfos(fn *types.Signature, qualifier types.Qualifier) ([]*gosyntax.FieldDeclInfo, error) {
 	paramInfos := []*gosyntax.FieldDeclInfo{}
 
 	tuples := fn.Results()
@@ -141,105 +374,218 @@ func GetFuncReturnInfosFromSignature(fn *types.Signature, mockPkg string) []*gos
 		for i := 0; i < tuples.Len(); i++ {
 			v := tuples.At(i)
 
+			typ, err := RenderQualifiedTypeDeclString(v.Type(), false, qualifier)
+			if err != nil {
+				return nil, err
+			}
+
 			paramInfos = append(paramInfos, &gosyntax.FieldDeclInfo{
 				Name:     v.Name(),
-				Typ:      RenderTypeDeclString(v.Type(), false, mockPkg),
+				Typ:      typ,
 				Variadic: false,
 			})
 		}
 	}
 
-	return paramInfos
+	return paramInfos, nil
 }
 
-func RenderTypeDeclString(t types.Type, variadic bool, mockPkg string) string {
+func RenderTypeDeclString(t types.Type, variadic bool, mockPkg string) (string, error) {
+	return RenderQualifiedTypeDeclString(t, variadic, PackageNameQualifier(mockPkg))
+}
+
+// RenderQualifiedTypeDeclString renders declarative string of a type, named types from
+// other packages are qualified with the import names returned from qualifier. An
+// error is returned if t is of a type that can not be rendered
+func RenderQualifiedTypeDeclString(t types.Type, variadic bool, qualifier types.Qualifier) (string, error) {
+	render := func(t types.Type) (string, error) {
+		return RenderQualifiedTypeDeclString(t, false, qualifier)
+	}
+
+	if obj, actual := unalias(t); obj != nil {
+		if hasTypeArgs(t) {
+			// instance of a generic alias, render the type it denotes
+			return RenderQualifiedTypeDeclString(actual, variadic, qualifier)
+		}
+
+		// keep alias names, predeclared ones such as any have no package
+		if obj.Pkg() != nil {
+			if name := qualifier(obj.Pkg()); name != "" {
+				return name + "." + obj.Name(), nil
+			}
+		}
+		return obj.Name(), nil
+	}
+
 	switch tt := t.(type) {
 	case *types.Basic:
-		return tt.Name()
+		return tt.Name(), nil
 	case *types.Slice:
+		elem, err := render(tt.Elem())
+		if err != nil {
+			return "", err
+		}
 		if variadic {
-			return "..." + RenderTypeDeclString(tt.Elem(), false, mockPkg)
+			return "..." + elem, nil
 		}
-		return "[]" + RenderTypeDeclString(tt.Elem(), false, mockPkg)
+		return "[]" + elem, nil
 	case *types.Array:
-		return fmt.Sprintf("[%d]%s", tt.Len(), RenderTypeDeclString(tt.Elem(), false, mockPkg))
+		elem, err := render(tt.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%d]%s", tt.Len(), elem), nil
 	case *types.Struct:
 		var fields []string
 
 		for i := 0; i < tt.NumFields(); i++ {
 			field := tt.Field(i)
 
+			typ, err := render(field.Type())
+			if err != nil {
+				return "", err
+			}
+
 			if field.Anonymous() {
-				fields = append(fields, RenderTypeDeclString(field.Type(), false, mockPkg))
+				fields = append(fields, typ)
 			} else {
-				fields = append(fields,
-					fmt.Sprintf("%s %s", field.Name(), RenderTypeDeclString(field.Type(), false, mockPkg)),
-				)
+				fields = append(fields, fmt.Sprintf("%s %s", field.Name(), typ))
 			}
 		}
-		return fmt.Sprintf("struct{%s}", strings.Join(fields, ";"))
+		return fmt.Sprintf("struct{%s}", strings.Join(fields, ";")), nil
 	case *types.Interface:
-		if tt.NumMethods() != 0 {
-			panic("Empty interface")
+		if tt.IsImplicit() {
+			// constraint literal in type parameter list, e.g. [T ~int | ~string]
+			return render(tt.EmbeddedType(0))
+		}
+
+		// embedded interfaces and type-set elements go first, followed by explicitly declared methods
+		var elems []string
+		for i := 0; i < tt.NumEmbeddeds(); i++ {
+			elem, err := render(tt.EmbeddedType(i))
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, elem)
+		}
+		for i := 0; i < tt.NumExplicitMethods(); i++ {
+			m := tt.ExplicitMethod(i)
+			sig, err := renderSignature(m.Type().(*types.Signature), qualifier)
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, m.Name()+sig)
+		}
+
+		if len(elems) > 0 {
+			return fmt.Sprintf("interface{%s}", strings.Join(elems, "; ")), nil
+		}
+		return "interface{}", nil
+
+	case *types.Union:
+		var terms []string
+
+		for i := 0; i < tt.Len(); i++ {
+			term := tt.Term(i)
+			typ, err := render(term.Type())
+			if err != nil {
+				return "", err
+			}
+			if term.Tilde() {
+				terms = append(terms, "~"+typ)
+			} else {
+				terms = append(terms, typ)
+			}
 		}
-		return "interface{}"
+		return strings.Join(terms, " | "), nil
+
+	case *types.TypeParam:
+		return tt.Obj().Name(), nil
 
 	case *types.Map:
-		key := RenderTypeDeclString(tt.Key(), false, mockPkg)
-		val := RenderTypeDeclString(tt.Elem(), false, mockPkg)
+		key, err := render(tt.Key())
+		if err != nil {
+			return "", err
+		}
+		val, err := render(tt.Elem())
+		if err != nil {
+			return "", err
+		}
 
-		return fmt.Sprintf("map[%s]%s", key, val)
+		return fmt.Sprintf("map[%s]%s", key, val), nil
 	case *types.Chan:
+		elem, err := render(tt.Elem())
+		if err != nil {
+			return "", err
+		}
+
 		switch tt.Dir() {
 		case types.SendRecv:
-			return "chan " + RenderTypeDeclString(tt.Elem(), false, mockPkg)
+			return "chan " + elem, nil
 		case types.RecvOnly:
-			return "<-chan " + RenderTypeDeclString(tt.Elem(), false, mockPkg)
+			return "<-chan " + elem, nil
 		default:
-			return "chan<- " + RenderTypeDeclString(tt.Elem(), false, mockPkg)
+			return "chan<- " + elem, nil
 		}
 
 	case *types.Pointer:
-		return "*" + RenderTypeDeclString(tt.Elem(), false, mockPkg)
+		elem, err := render(tt.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "*" + elem, nil
 
 	case *types.Signature:
-		switch tt.Results().Len() {
-		case 0:
-			return fmt.Sprintf(
-				"func(%s)",
-				RenderTypeDeclString(tt.Params(), false, mockPkg),
-			)
-		case 1:
-			return fmt.Sprintf(
-				"func(%s) %s",
-				RenderTypeDeclString(tt.Params(), false, mockPkg),
-				RenderTypeDeclString(tt.Results().At(0).Type(), false, mockPkg),
-			)
-		default:
-			return fmt.Sprintf(
-				"func(%s)(%s)",
-				RenderTypeDeclString(tt.Params(), false, mockPkg),
-				RenderTypeDeclString(tt.Results(), false, mockPkg),
-			)
+		sig, err := renderSignature(tt, qualifier)
+		if err != nil {
+			return "", err
 		}
+		return "func" + sig, nil
+
 	case *types.Tuple:
 		var parts []string
 
 		for i := 0; i < tt.Len(); i++ {
-			part := tt.At(i)
-			parts = append(parts, RenderTypeDeclString(part.Type(), false, mockPkg))
+			part, err := render(tt.At(i).Type())
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
 		}
 
-		return strings.Join(parts, ", ")
+		return strings.Join(parts, ", "), nil
 
 	case *types.Named:
 		o := tt.Obj()
-		if o.Pkg() == nil || o.Pkg().Name() == "main" || o.Pkg().Name() == mockPkg {
-			return o.Name()
+
+		var typeArgs string
+		if tt.TypeArgs().Len() > 0 {
+			var args []string
+			for i := 0; i < tt.TypeArgs().Len(); i++ {
+				arg, err := render(tt.TypeArgs().At(i))
+				if err != nil {
+					return "", err
+				}
+				args = append(args, arg)
+			}
+			typeArgs = "[" + strings.Join(args, ", ") + "]"
 		}
-		return o.Pkg().Name() + "." + o.Name()
+
+		if name := qualifier(o.Pkg()); name != "" {
+			return name + "." + o.Name() + typeArgs, nil
+		}
+		return o.Name() + typeArgs, nil
 
 	default:
-		panic(fmt.Sprintf("Unsupported type: %#v (%T)", t, tt))
+		return "", fmt.Errorf("unsupported type %s (%T)", t, t)
+	}
+}
+
+// hasTypeArgs tells if t is an instance of a generic alias, which is only
+// supported by toolchains since go1.24
+func hasTypeArgs(t types.Type) bool {
+	if instance, ok := t.(interface{ TypeArgs() *types.TypeList }); ok {
+		return instance.TypeArgs().Len() > 0
 	}
+	return false
 }
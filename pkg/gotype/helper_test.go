@@ -1,8 +1,10 @@
 package gotype
 
 import (
+	"go/types"
 	"testing"
 
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/packages"
 )
@@ -24,10 +26,12 @@ func TestFieldInfoFromSignature(t *testing.T) {
 	fn := FindFuncSignature(pkgs[0], "GetSecrets")
 	assert.True(fn != nil)
 
-	paramInfos := GetFuncParamInfosFromSignature(fn, "")
+	paramInfos, err := GetFuncParamInfosFromSignature(fn, "")
+	assert.NoError(err)
 	assert.True(paramInfos != nil)
 
-	returnInfos := GetFuncReturnInfosFromSignature(fn, "")
+	returnInfos, err := GetFuncReturnInfosFromSignature(fn, "")
+	assert.NoError(err)
 	assert.True(returnInfos != nil)
 
 	intf := FindInterfaceMethodSignature(pkgs[0], "SecretsInterface", "GetSecrets")
@@ -47,10 +51,12 @@ func TestLoadDotPackage(t *testing.T) {
 	fn := FindFuncSignature(pkgs[0], "RenderTypeDeclString")
 	assert.NotNil(fn)
 
-	paramInfos := GetFuncParamInfosFromSignature(fn, "")
+	paramInfos, err := GetFuncParamInfosFromSignature(fn, "")
+	assert.NoError(err)
 	assert.True(paramInfos != nil)
 
-	returnInfos := GetFuncReturnInfosFromSignature(fn, "")
+	returnInfos, err := GetFuncReturnInfosFromSignature(fn, "")
+	assert.NoError(err)
 	assert.True(returnInfos != nil)
 }
 
@@ -70,9 +76,146 @@ func TestVariadicFieldInfoFromSignature(t *testing.T) {
 	fn := FindFuncSignature(pkgs[0], "Sprintf")
 	assert.True(fn != nil)
 
-	paramInfos := GetFuncParamInfosFromSignature(fn, "")
+	paramInfos, err := GetFuncParamInfosFromSignature(fn, "")
+	assert.NoError(err)
 	assert.True(paramInfos != nil)
 
-	returnInfos := GetFuncReturnInfosFromSignature(fn, "")
+	returnInfos, err := GetFuncReturnInfosFromSignature(fn, "")
+	assert.NoError(err)
 	assert.True(returnInfos != nil)
 }
+
+func TestRenderGenericInterfaceTypeParams(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedSyntax}
+
+	pkgs, err := packages.Load(cfg, "github.com/kelveny/mockcompose/test/generic")
+	assert.NoError(err)
+	assert.Equal(1, len(pkgs))
+
+	render := func(decl string, err error) string {
+		assert.NoError(err)
+		return decl
+	}
+
+	tparams := FindInterfaceTypeParams(pkgs[0], "Repo")
+	assert.Equal("[T any]", render(RenderTypeParamsDeclString(tparams, "generic")))
+	assert.Equal("[T]", RenderTypeParamsNameString(tparams))
+
+	tparams = FindInterfaceTypeParams(pkgs[0], "Aggregator")
+	assert.Equal("[K comparable, V Number]", render(RenderTypeParamsDeclString(tparams, "generic")))
+	assert.Equal("[K comparable, V generic.Number]", render(RenderTypeParamsDeclString(tparams, "other")))
+	assert.Equal("[K, V]", RenderTypeParamsNameString(tparams))
+
+	tparams = FindInterfaceTypeParams(pkgs[0], "Ordered")
+	assert.Equal("[T ~int | ~string]", render(RenderTypeParamsDeclString(tparams, "generic")))
+
+	sig := FindInterfaceMethodSignature(pkgs[0], "Aggregator", "Snapshot")
+	assert.NotNil(sig)
+	returnInfos, err := GetFuncReturnInfosFromSignature(sig, "generic")
+	assert.NoError(err)
+	assert.Equal("map[K]V", returnInfos[0].Typ)
+
+	number := pkgs[0].Types.Scope().Lookup("Number")
+	assert.Equal("interface{~int | ~int64 | ~float64}", render(RenderTypeDeclString(number.Type().Underlying(), false, "generic")))
+
+	assert.Equal("", render(RenderTypeParamsDeclString(FindInterfaceTypeParams(pkgs[0], "memRepo"), "generic")))
+}
+
+func TestRenderInterfaceLiterals(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedSyntax}
+
+	pkgs, err := packages.Load(cfg, "github.com/kelveny/mockcompose/test/libfn")
+	assert.NoError(err)
+	assert.Equal(1, len(pkgs))
+
+	params := func(fn *types.Signature) []*gosyntax.FieldDeclInfo {
+		infos, err := GetFuncParamInfosFromSignature(fn, "")
+		assert.NoError(err)
+		return infos
+	}
+
+	fn := FindFuncSignature(pkgs[0], "Register")
+	assert.NotNil(fn)
+	assert.Equal("interface{Handle() error}", params(fn)[0].Typ)
+
+	fn = FindFuncSignature(pkgs[0], "Open")
+	assert.NotNil(fn)
+	returnInfos, err := GetFuncReturnInfosFromSignature(fn, "")
+	assert.NoError(err)
+	assert.Equal("interface{io.Reader; io.Closer}", returnInfos[0].Typ)
+
+	fn = FindInterfaceMethodSignature(pkgs[0], "ResourceInterface", "Watch")
+	assert.NotNil(fn)
+	assert.Equal("interface{Match(string, ...string) bool}", params(fn)[0].Typ)
+
+	decl, err := RenderTypeDeclString(
+		types.NewInterfaceType(
+			[]*types.Func{
+				types.NewFunc(0, nil, "Close", types.NewSignatureType(nil, nil, nil, nil,
+					types.NewTuple(
+						types.NewVar(0, nil, "", types.Typ[types.Bool]),
+						types.NewVar(0, nil, "", types.Universe.Lookup("error").Type()),
+					), false),
+				),
+			},
+			[]types.Type{pkgs[0].Types.Scope().Lookup("SecretsInterface").Type()},
+		),
+		false,
+		"",
+	)
+	assert.NoError(err)
+	assert.Equal("interface{libfn.SecretsInterface; Close() (bool, error)}", decl)
+}
+
+// unknownType is a type that is not known to the renderer
+type unknownType struct{}
+
+func (unknownType) Underlying() types.Type { return unknownType{} }
+func (unknownType) String() string         { return "unknown" }
+
+func TestRenderUnsupportedType(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := RenderTypeDeclString(types.NewSlice(unknownType{}), false, "")
+	assert.Error(err)
+	assert.Contains(err.Error(), "unsupported type unknown")
+
+	sig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewVar(0, nil, "v", types.NewMap(types.Typ[types.String], unknownType{}))),
+		nil, false)
+	_, err = GetFuncParamInfosFromSignature(sig, "")
+	assert.Error(err)
+}
+
+func TestHasLock(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax}
+
+	pkgs, err := packages.Load(cfg, "sync", "github.com/kelveny/mockcompose/test/race")
+	assert.NoError(err)
+	assert.Equal(2, len(pkgs))
+
+	typeOf := func(pkgPath string, name string) types.Type {
+		for _, pkg := range pkgs {
+			if pkg.PkgPath == pkgPath {
+				return pkg.Types.Scope().Lookup(name).Type()
+			}
+		}
+		return nil
+	}
+
+	assert.True(HasLock(typeOf("sync", "Mutex")))
+	assert.True(HasLock(typeOf("sync", "WaitGroup")))
+	assert.False(HasLock(types.NewPointer(typeOf("sync", "Mutex"))))
+	assert.False(HasLock(typeOf("sync", "Locker")))
+
+	race := typeOf("github.com/kelveny/mockcompose/test/race", "race")
+	assert.True(HasLock(race))
+	assert.True(HasLock(types.NewArray(race, 2)))
+	assert.False(HasLock(typeOf("github.com/kelveny/mockcompose/test/race", "Race")))
+}
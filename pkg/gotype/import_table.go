@@ -0,0 +1,166 @@
+package gotype
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+)
+
+// ImportTable tracks imports of a generated file. It binds every imported package
+// path to an import name that is unique within the file, so that types from different
+// packages sharing the same package name (e.g. k8s.io/api/core/v1 and k8s.io/api/apps/v1)
+// can be rendered side by side.
+//
+// Imports of the source file are registered first with AddImport, their aliases
+// are then reused when qualifying types from the same packages.
+type ImportTable struct {
+	mockPkgName string // name of the package that generated file resides
+	mockPkgPath string // path of the package that generated file resides, may be empty
+
+	specs []gosyntax.ImportSpec
+	names map[string]string // import name -> package path
+	paths map[string]string // package path -> import name, empty for default name
+}
+
+func NewImportTable(mockPkgName, mockPkgPath string) *ImportTable {
+	return &ImportTable{
+		mockPkgName: mockPkgName,
+		mockPkgPath: mockPkgPath,
+		names:       make(map[string]string),
+		paths:       make(map[string]string),
+	}
+}
+
+// AddImport registers an import from source code, name is empty if the import
+// is not aliased. Imports with conflicting names are ignored, packages of these
+// imports will be bound to unique names when they are referenced.
+func (t *ImportTable) AddImport(name, p string) {
+	if name == "_" || name == "." {
+		return
+	}
+
+	bindName := name
+	if bindName == "" {
+		bindName = guessImportName(p)
+	}
+
+	if bound, ok := t.names[bindName]; ok && bound != p {
+		return
+	}
+	t.names[bindName] = p
+
+	// the first import wins when a package is imported multiple times
+	if _, ok := t.paths[p]; !ok {
+		t.paths[p] = name
+	}
+	t.specs = gosyntax.AppendImportSpec(t.specs, name, p)
+}
+
+// AddImports registers a list of imports from source code
+func (t *ImportTable) AddImports(specs []gosyntax.ImportSpec) {
+	for _, spec := range specs {
+		t.AddImport(spec.Name, spec.Path)
+	}
+}
+
+// Qualifier implements types.Qualifier, it returns the import name for pkg,
+// registering an import for it if necessary
+func (t *ImportTable) Qualifier(pkg *types.Package) string {
+	if pkg == nil || pkg.Name() == "main" || t.isMockPkg(pkg) {
+		return ""
+	}
+
+	if name, ok := t.paths[pkg.Path()]; ok {
+		if name != "" {
+			return name
+		}
+
+		// imported without alias, the import name is the package name
+		if bound, ok := t.names[pkg.Name()]; !ok || bound == pkg.Path() {
+			t.names[pkg.Name()] = pkg.Path()
+			t.paths[pkg.Path()] = pkg.Name()
+			return pkg.Name()
+		}
+	}
+
+	name := t.uniqueName(pkg)
+	t.names[name] = pkg.Path()
+	t.paths[pkg.Path()] = name
+	if name == pkg.Name() {
+		t.specs = gosyntax.AppendImportSpec(t.specs, "", pkg.Path())
+	} else {
+		t.specs = append(t.specs, gosyntax.ImportSpec{Name: name, Path: pkg.Path()})
+	}
+
+	return name
+}
+
+// Imports returns imports in the order of registration
+func (t *ImportTable) Imports() []gosyntax.ImportSpec {
+	return t.specs
+}
+
+func (t *ImportTable) isMockPkg(pkg *types.Package) bool {
+	if t.mockPkgPath != "" {
+		return pkg.Path() == t.mockPkgPath
+	}
+
+	// without knowing the path of the generated package, match it by name
+	return pkg.Name() == t.mockPkgName
+}
+
+func (t *ImportTable) isNameTaken(name string) bool {
+	if _, ok := t.names[name]; ok {
+		return true
+	}
+
+	// package name of the generated file can only be taken when its path is known,
+	// otherwise package of that name is considered as the generated package
+	return t.mockPkgPath != "" && name == t.mockPkgName
+}
+
+// uniqueName picks an import name for pkg, in the order of package name,
+// package name prefixed with its parent directory (e.g. appsv1) and package
+// name with a numeric suffix
+func (t *ImportTable) uniqueName(pkg *types.Package) string {
+	if !t.isNameTaken(pkg.Name()) {
+		return pkg.Name()
+	}
+
+	if parent := sanitizeImportName(path.Base(path.Dir(pkg.Path()))); parent != "" && parent != "." {
+		if name := parent + pkg.Name(); !t.isNameTaken(name) {
+			return name
+		}
+	}
+
+	for i := 2; ; i++ {
+		if name := fmt.Sprintf("%s%d", pkg.Name(), i); !t.isNameTaken(name) {
+			return name
+		}
+	}
+}
+
+// guessImportName guesses default import name from import path, it is only used
+// to reserve names of source imports, the real package name is used when qualifying
+func guessImportName(p string) string {
+	name := path.Base(p)
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+
+	return sanitizeImportName(name)
+}
+
+func sanitizeImportName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+}
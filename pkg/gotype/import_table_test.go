@@ -0,0 +1,75 @@
+package gotype
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTableCollision(t *testing.T) {
+	assert := require.New(t)
+
+	core := types.NewPackage("k8s.io/api/core/v1", "v1")
+	apps := types.NewPackage("k8s.io/api/apps/v1", "v1")
+	batch := types.NewPackage("k8s.io/api/apps/v1beta1/v1", "v1")
+
+	table := NewImportTable("mocks", "")
+	assert.Equal("v1", table.Qualifier(core))
+	assert.Equal("appsv1", table.Qualifier(apps))
+	assert.Equal("v1beta1v1", table.Qualifier(batch))
+
+	// bound names are stable
+	assert.Equal("v1", table.Qualifier(core))
+	assert.Equal("appsv1", table.Qualifier(apps))
+
+	assert.Equal([]gosyntax.ImportSpec{
+		{Name: "", Path: "k8s.io/api/core/v1"},
+		{Name: "appsv1", Path: "k8s.io/api/apps/v1"},
+		{Name: "v1beta1v1", Path: "k8s.io/api/apps/v1beta1/v1"},
+	}, table.Imports())
+}
+
+func TestImportTableReusesSourceAliases(t *testing.T) {
+	assert := require.New(t)
+
+	core := types.NewPackage("k8s.io/api/core/v1", "v1")
+	apps := types.NewPackage("k8s.io/api/apps/v1", "v1")
+	yaml := types.NewPackage("gopkg.in/yaml.v2", "yaml")
+
+	table := NewImportTable("mocks", "")
+	table.AddImports([]gosyntax.ImportSpec{
+		{Name: "corev1", Path: "k8s.io/api/core/v1"},
+		{Name: "", Path: "k8s.io/api/apps/v1"},
+		{Name: "", Path: "gopkg.in/yaml.v2"},
+		{Name: "_", Path: "embed"},
+	})
+
+	assert.Equal("corev1", table.Qualifier(core))
+	assert.Equal("v1", table.Qualifier(apps))
+	assert.Equal("yaml", table.Qualifier(yaml))
+
+	assert.Equal([]gosyntax.ImportSpec{
+		{Name: "corev1", Path: "k8s.io/api/core/v1"},
+		{Name: "", Path: "k8s.io/api/apps/v1"},
+		{Name: "", Path: "gopkg.in/yaml.v2"},
+	}, table.Imports())
+}
+
+func TestImportTableMockPackage(t *testing.T) {
+	assert := require.New(t)
+
+	local := types.NewPackage("example.com/app/mocks", "mocks")
+	other := types.NewPackage("example.com/lib/mocks", "mocks")
+
+	// without package path, package of the same name is treated as local package
+	table := NewImportTable("mocks", "")
+	assert.Equal("", table.Qualifier(local))
+	assert.Equal("", table.Qualifier(other))
+	assert.Equal("", table.Qualifier(nil))
+
+	table = NewImportTable("mocks", "example.com/app/mocks")
+	assert.Equal("", table.Qualifier(local))
+	assert.Equal("libmocks", table.Qualifier(other))
+}
@@ -1,6 +1,14 @@
 package logger
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
 
 // simple verbose/non-verbose logging control
 const (
@@ -10,11 +18,130 @@ const (
 	ERROR
 )
 
-var (
-	LogLevel int = int(PROMPT)
+type MessageType int
+
+func (t MessageType) String() string {
+	switch t {
+	case VERBOSE:
+		return "verbose"
+	case PROMPT:
+		return "info"
+	case WARN:
+		return "warn"
+	case ERROR:
+		return "error"
+	}
+	return strconv.Itoa(int(t))
+}
+
+// Format is the format of log messages
+type Format int
+
+const (
+	// Text writes messages as human readable lines, colored on terminals
+	Text Format = iota
+	// KeyValue writes every message as a line of key=value pairs, i.e.,
+	// level=warn pos=foo.go:12:2 msg="unable to resolve embedded interfaces"
+	KeyValue
 )
 
-type MessageType int
+// ParseFormat parses name of a format, text or kv
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "text":
+		return Text, nil
+	case "kv":
+		return KeyValue, nil
+	}
+	return Text, fmt.Errorf("unknown log format %s, text or kv is expected", name)
+}
+
+// Logger writes log messages to a writer, every message is written with a single
+// Write call so that messages from concurrent goroutines are not interleaved.
+//
+// A logger is immutable once created, With* methods return a copy with the
+// setting changed, copies that share a writer share its lock as well
+type Logger struct {
+	mutex  *sync.Mutex
+	writer io.Writer
+	level  MessageType
+	color  bool
+	format Format
+	fields []string // key=value pairs written with every message in KeyValue format
+}
+
+// Default is the logger used by package level Log
+var Default = New(os.Stderr)
+
+// New creates a logger that writes messages of PROMPT level and above in Text
+// format, messages are colored if writer is a terminal and NO_COLOR is not set
+func New(writer io.Writer) *Logger {
+	return &Logger{
+		mutex:  &sync.Mutex{},
+		writer: writer,
+		level:  PROMPT,
+		color:  useColor(writer),
+	}
+}
+
+// useColor tells if messages written to writer should be colored, see https://no-color.org
+func useColor(writer io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	f, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// WithWriter returns a copy of the logger that writes to writer, i.e., to collect
+// messages that are written to the logger later. Color setting is kept
+func (l *Logger) WithWriter(writer io.Writer) *Logger {
+	c := *l
+	c.mutex = &sync.Mutex{}
+	c.writer = writer
+	return &c
+}
+
+// WithLevel returns a copy of the logger that discards messages below level, a
+// quiet logger has level of WARN
+func (l *Logger) WithLevel(level MessageType) *Logger {
+	c := *l
+	c.level = level
+	return &c
+}
+
+// WithColor returns a copy of the logger with color turned on or off
+func (l *Logger) WithColor(color bool) *Logger {
+	c := *l
+	c.color = color
+	return &c
+}
+
+// WithFormat returns a copy of the logger that writes messages in format
+func (l *Logger) WithFormat(format Format) *Logger {
+	c := *l
+	c.format = format
+	return &c
+}
+
+// With returns a copy of the logger that writes key=value with every message in
+// KeyValue format, i.e., name of the configuration entry a message belongs to
+func (l *Logger) With(key string, value string) *Logger {
+	c := *l
+	c.fields = append(append([]string{}, l.fields...), key+"="+quote(value))
+	return &c
+}
+
+// Enabled tells if messages of msgType are written
+func (l *Logger) Enabled(msgType MessageType) bool {
+	return msgType >= l.level
+}
 
 func getLevelColor(msgType MessageType) string {
 	colorRed := "\033[31m"
@@ -34,18 +161,82 @@ func getLevelColor(msgType MessageType) string {
 }
 
 func Log(msgType MessageType, format string, args ...interface{}) {
-	colorReset := "\033[0m"
+	Default.Log(msgType, format, args...)
+}
+
+func (l *Logger) Log(msgType MessageType, format string, args ...interface{}) {
+	l.LogAt(msgType, "", format, args...)
+}
+
+// LogAt logs a message about source code at pos, which is in file:line:column
+// format, pos is omitted if empty
+func (l *Logger) LogAt(msgType MessageType, pos string, format string, args ...interface{}) {
+	if !l.Enabled(msgType) {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	var b bytes.Buffer
+	if l.format == KeyValue {
+		msg = strings.TrimSpace(msg)
+		if msg == "" {
+			// blank lines only separate human readable output
+			return
+		}
+
+		b.WriteString("level=" + msgType.String())
+		if pos != "" {
+			b.WriteString(" pos=" + quote(pos))
+		}
+		for _, field := range l.fields {
+			b.WriteString(" " + field)
+		}
+		b.WriteString(" msg=" + quote(msg) + "\n")
+	} else {
+		if strings.TrimSpace(msg) == "" {
+			b.WriteString(msg)
+			l.Write(b.Bytes())
+			return
+		}
+
+		colorReset := "\033[0m"
 
-	if msgType >= MessageType(LogLevel) {
-		color := getLevelColor(msgType)
+		color := ""
+		if l.color {
+			color = getLevelColor(msgType)
+		}
 		if color != "" {
-			fmt.Print(color)
+			b.WriteString(color)
+		}
+		b.WriteString("mockcompose - ")
+		if pos != "" {
+			b.WriteString(pos + ": ")
 		}
-		fmt.Print("mockcompose - ")
-		fmt.Printf(format, args...)
+		b.WriteString(msg)
 
 		if color != "" {
-			fmt.Print(colorReset)
+			b.WriteString(colorReset)
 		}
 	}
+
+	l.Write(b.Bytes())
+}
+
+// Write writes content as is, i.e., log messages collected by another logger
+func (l *Logger) Write(p []byte) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.writer.Write(p)
+}
+
+// quote quotes value if it is empty or contains spaces, quotes or control characters
+func quote(value string) string {
+	if value == "" || strings.IndexFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '"' || r == '=' || r == 0x7f
+	}) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
 }
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	log := New(&b)
+
+	log.Log(VERBOSE, "not logged\n")
+	log.Log(PROMPT, "Scan package %s...\n", "foo")
+	log.LogAt(WARN, "foo.go:3:2", "Unable to resolve embedded interfaces of %s\n", "Foo")
+	log.Log(PROMPT, "\n")
+	assert.Equal("mockcompose - Scan package foo...\n"+
+		"mockcompose - foo.go:3:2: Unable to resolve embedded interfaces of Foo\n"+
+		"\n", b.String())
+
+	b.Reset()
+	log.WithColor(true).Log(ERROR, "failed\n")
+	assert.Equal("\033[31mmockcompose - failed\n\033[0m", b.String())
+
+	b.Reset()
+	quiet := log.WithLevel(WARN)
+	quiet.Log(PROMPT, "Scan package %s...\n", "foo")
+	quiet.Log(WARN, "warning\n")
+	assert.Equal("mockcompose - warning\n", b.String())
+}
+
+func TestLogger_keyValue(t *testing.T) {
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	log := New(&b).WithColor(true).WithFormat(KeyValue).With("entry", "mockFoo")
+
+	log.Log(PROMPT, "Scan package %s...\n\n", "foo")
+	log.Log(PROMPT, "\n")
+	log.LogAt(ERROR, "foo.go:3:2", "expected 'IDENT', found \"}\"\n")
+	assert.Equal(`level=info entry=mockFoo msg="Scan package foo..."
+level=error pos=foo.go:3:2 entry=mockFoo msg="expected 'IDENT', found \"}\""
+`, b.String())
+}
+
+func TestParseFormat(t *testing.T) {
+	assert := require.New(t)
+
+	format, err := ParseFormat("kv")
+	assert.NoError(err)
+	assert.Equal(KeyValue, format)
+
+	_, err = ParseFormat("json")
+	assert.Error(err)
+}
@@ -1,23 +1,23 @@
-package cmd
+package mockcompose
 
 import (
 	"bytes"
 	"fmt"
 	"go/ast"
-	"go/format"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io"
 	"strings"
 
 	"golang.org/x/exp/slices"
 
 	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/goload"
 	"github.com/kelveny/mockcompose/pkg/gosyntax"
 	gosyntaxtyp "github.com/kelveny/mockcompose/pkg/gosyntax"
 
 	"github.com/kelveny/mockcompose/pkg/gotype"
-	"github.com/kelveny/mockcompose/pkg/logger"
 )
 
 const (
@@ -29,17 +29,29 @@ const (
 type matchType int
 
 type classMethodGenerator struct {
+	dir    string         // directory of the package
+	loader *goload.Loader // loader of packages
+
 	clzName     string // name of the class that implements class interface
 	mockPkgName string // package name that mocking class resides
 	mockName    string // the mocking composite class name
 
 	methodsToClone []string // method function names that need to be cloned in mocking class
 	methodsToMock  []string // method function names that need to be mocked
+	backend        gogen.Backend
+	summary        *Summary
+	log            *reporter
 }
 
 type generatorContext struct {
 	mockedFunctions map[string]any
 
+	// imports of generated file
+	imports *gotype.ImportTable
+
+	// composite class being generated
+	mockClz *gogen.MockClz
+
 	// class type declaraion string -> method name -> *ReceiverSpec
 	clzMethods map[string]map[string]*gosyntax.ReceiverSpec
 }
@@ -64,14 +76,14 @@ func (c *generatorContext) recordMockedFunction(fnName string) {
 func (c *generatorContext) findClassMethods(
 	clzTypeDeclString string,
 	fset *token.FileSet,
-	f *ast.File,
+	files []*ast.File,
 ) map[string]*gosyntaxtyp.ReceiverSpec {
 	if c.clzMethods == nil {
 		c.clzMethods = make(map[string]map[string]*gosyntaxtyp.ReceiverSpec)
 	}
 
 	if _, ok := c.clzMethods[clzTypeDeclString]; !ok {
-		c.clzMethods[clzTypeDeclString] = gosyntax.FindClassMethods(clzTypeDeclString, fset, f)
+		c.clzMethods[clzTypeDeclString] = gosyntax.FindClassMethods(clzTypeDeclString, fset, files...)
 	}
 
 	return c.clzMethods[clzTypeDeclString]
@@ -79,25 +91,14 @@ func (c *generatorContext) findClassMethods(
 
 // use compiler to enforce interface compliance
 var _ parsedFileGenerator = (*classMethodGenerator)(nil)
+var _ parsedPackageGenerator = (*classMethodGenerator)(nil)
 
 // match checks if a FuncDecl matches condition
 func (g *classMethodGenerator) match(fnSpec *ast.FuncDecl) (bool, matchType) {
 	if fnSpec.Recv != nil {
-		t := fnSpec.Recv.List[0].Type
-
-		if expr, ok := t.(*ast.StarExpr); ok {
-			recvrClzName := expr.X.(*ast.Ident).Name
-			if g.clzName == recvrClzName {
-				if matchType := g.matchNameInConfig(fnSpec.Name.Name); matchType != MATCH_NONE {
-					return true, matchType
-				}
-			}
-		} else {
-			recvrClzName := t.(*ast.Ident).Name
-			if g.clzName == recvrClzName {
-				if matchType := g.matchNameInConfig(fnSpec.Name.Name); matchType != MATCH_NONE {
-					return true, matchType
-				}
+		if recvrClzName := getReceiverTypeName(fnSpec); g.clzName == recvrClzName {
+			if matchType := g.matchNameInConfig(fnSpec.Name.Name); matchType != MATCH_NONE {
+				return true, matchType
 			}
 		}
 	} else {
@@ -111,23 +112,64 @@ func (g *classMethodGenerator) match(fnSpec *ast.FuncDecl) (bool, matchType) {
 }
 
 func getReceiverTypeName(fnSpec *ast.FuncDecl) string {
-	t := fnSpec.Recv.List[0].Type
-
-	if expr, ok := t.(*ast.StarExpr); ok {
-		return expr.X.(*ast.Ident).Name
-	} else {
-		return t.(*ast.Ident).Name
+	if ident := gosyntax.ReceiverTypeIdent(fnSpec.Recv); ident != nil {
+		return ident.Name
 	}
+	return ""
 }
 
 func changeReceiverTypeName(fnSpec *ast.FuncDecl, name string) {
-	t := fnSpec.Recv.List[0].Type
+	if ident := gosyntax.ReceiverTypeIdent(fnSpec.Recv); ident != nil {
+		ident.Name = name
+	}
+}
 
-	if expr, ok := t.(*ast.StarExpr); ok {
-		expr.X.(*ast.Ident).Name = name
-	} else {
-		t.(*ast.Ident).Name = name
+// getMockClz returns composite class that embeds the source class
+func (g *classMethodGenerator) getMockClz(
+	fset *token.FileSet,
+	files []*ast.File,
+) *gogen.MockClz {
+	typeParamsDecl, typeParamNames := g.getClassTypeParams(fset, files)
+	return &gogen.MockClz{
+		Name:           g.mockName,
+		TypeParamsDecl: typeParamsDecl,
+		TypeParamNames: typeParamNames,
+		Composite:      true,
+		SrcField:       g.clzName,
+		SrcType:        g.clzName + typeParamNames,
+		SrcLocked:      g.isClassLocked(),
+	}
+}
+
+// isClassLocked tells if the source class contains locks, which go vet does not
+// allow to be copied. Class is assumed to have no locks if the package can not
+// be loaded
+func (g *classMethodGenerator) isClassLocked() bool {
+	pkg, err := g.loader.LoadPackage(g.dir, ".")
+	if err != nil || pkg.Types == nil {
+		return false
+	}
+
+	if obj, ok := pkg.Types.Scope().Lookup(g.clzName).(*types.TypeName); ok {
+		return gotype.HasLock(obj.Type())
 	}
+	return false
+}
+
+// getClassTypeParams returns type parameter declaration string and type parameter
+// names of the source class, both are empty strings for non-generic class
+func (g *classMethodGenerator) getClassTypeParams(
+	fset *token.FileSet,
+	files []*ast.File,
+) (typeParamsDecl string, typeParamNames string) {
+	for _, file := range files {
+		if tspec := gosyntax.FindTypeSpec(file, g.clzName); tspec != nil {
+			return gosyntax.TypeParamListDeclString(fset, tspec.TypeParams),
+				gosyntax.TypeParamListNameString(tspec.TypeParams)
+		}
+	}
+
+	return "", ""
 }
 
 func (g *classMethodGenerator) matchNameInConfig(fnName string) matchType {
@@ -176,7 +218,7 @@ func (g *classMethodGenerator) getMethodOverrides(
 						if kv[0] != "." && kv[0] != "this" {
 							overrides[kv[0]] = kv[1]
 						} else {
-							logger.Log(logger.ERROR, "invalid package override usage: %s", pair)
+							g.log.fail(UsageError, "invalid package override usage: %s\n", pair)
 						}
 					} else {
 						switch kv[0] {
@@ -241,12 +283,14 @@ func (g *classMethodGenerator) composeMock(
 	writer io.Writer,
 	fset *token.FileSet,
 	fnSpec *ast.FuncDecl,
-) {
+) bool {
 	if !generatorCtx.hasFunctionMocked(fnSpec.Name.Name) {
+		// for method of a generic class, type parameter names are taken from the source method receiver
 		gogen.MockFunc(
 			writer,
-			g.mockPkgName,
-			g.mockName,
+			g.backend,
+			generatorCtx.imports.Qualifier,
+			generatorCtx.mockClz.WithTypeParamNames(gosyntax.ReceiverTypeParamNameString(fnSpec.Recv)),
 			fset,
 			fnSpec.Name.Name,
 			fnSpec.Type.Params,
@@ -255,48 +299,43 @@ func (g *classMethodGenerator) composeMock(
 		)
 
 		generatorCtx.recordMockedFunction(fnSpec.Name.Name)
+		return true
 	}
+	return false
 }
 
 func (g *classMethodGenerator) generate(
 	writer io.Writer,
 	file *ast.File,
+) error {
+	return g.generateViaParsedPackage(writer, []*ast.File{file})
+}
+
+// generateViaParsedPackage generates composite class with methods, peer callees and
+// imports gathered from all files of the package
+func (g *classMethodGenerator) generateViaParsedPackage(
+	writer io.Writer,
+	files []*ast.File,
 ) error {
 	var buf bytes.Buffer
 
 	fset := token.NewFileSet()
-	if ok, autoMockPkgs := g.generateInternal(&buf, fset, file); ok {
+	if ok, autoMockPkgs := g.generateInternal(&buf, fset, files); ok {
 		// reload generated content to process generated code the second time
 		f, err := parser.ParseFile(fset, "", buf.Bytes(), parser.ParseComments)
 		if err != nil {
-			logger.Log(logger.ERROR, "Internal error: %s\n\n%s\n", err, buf.String())
-			return err
+			return fmt.Errorf("internal error: %s\n\n%s", err, buf.String())
 		}
 
 		// remove unused imports
-		var cleanedImports []gosyntax.ImportSpec = []gosyntax.ImportSpec{
-			{
-				Name: "mock",
-				Path: "github.com/stretchr/testify/mock",
-			},
-		}
-		cleanedImports = gogen.CleanImports(f, cleanedImports)
+		cleanedImports := gogen.CleanImports(f, g.backend.Imports())
 
 		// compose final output
-		fmt.Fprintf(writer, header, g.mockPkgName)
-
-		gogen.WriteImportDecls(writer, cleanedImports)
-		fmt.Fprintf(writer, compositeClzTemplateBegin, g.mockName, g.clzName, "mock.Mock")
-		for _, mockedPkgClz := range autoMockPkgs {
-			fmt.Fprintf(writer, "	%s\n", mockedPkgClz)
-		}
-		fmt.Fprint(writer, compositeClzTemplateEnd)
-
+		clz := g.getMockClz(fset, files)
 		for _, mockedPkgClz := range autoMockPkgs {
-			fmt.Fprintf(writer, mockClzTemplate, mockedPkgClz, "mock.Mock")
+			clz.PkgMocks = append(clz.PkgMocks, &gogen.MockClz{Name: mockedPkgClz})
 		}
-
-		gogen.WriteFuncDecls(writer, fset, f)
+		return writeMockFile(writer, g.backend, g.mockPkgName, cleanedImports, clz, fset, f)
 	}
 
 	return nil
@@ -305,11 +344,38 @@ func (g *classMethodGenerator) generate(
 func (g *classMethodGenerator) generateInternal(
 	writer io.Writer,
 	fset *token.FileSet,
-	file *ast.File,
+	files []*ast.File,
 ) (generated bool, autoMockPkgs []string) {
+	generatorCtx := &generatorContext{
+		imports: gotype.NewImportTable(g.mockPkgName, ""),
+		mockClz: g.getMockClz(fset, files),
+	}
+	for _, file := range files {
+		generatorCtx.imports.AddImports(gosyntax.GetFileImports(file))
+	}
+
+	// generate functions first, imports are complete only after all callee signatures are rendered
+	var body bytes.Buffer
+	for _, file := range files {
+		fileGenerated, fileAutoMockPkgs := g.generateFuncDecls(generatorCtx, &body, fset, files, file)
+		generated = generated || fileGenerated
+		autoMockPkgs = append(autoMockPkgs, fileAutoMockPkgs...)
+	}
+
 	writer.Write([]byte(fmt.Sprintf("package %s\n\n", g.mockPkgName)))
+	gogen.WriteImportDecls(writer, generatorCtx.imports.Imports())
+	writer.Write(body.Bytes())
+
+	return
+}
 
-	generatorCtx := &generatorContext{}
+func (g *classMethodGenerator) generateFuncDecls(
+	generatorCtx *generatorContext,
+	writer io.Writer,
+	fset *token.FileSet,
+	files []*ast.File,
+	file *ast.File,
+) (generated bool, autoMockPkgs []string) {
 	imports := gosyntax.GetFileImportsAsMap(file)
 
 	if len(file.Decls) > 0 {
@@ -321,6 +387,8 @@ func (g *classMethodGenerator) generateInternal(
 				}
 
 				if matchType == MATCH_CLONE {
+					g.summary.Cloned = append(g.summary.Cloned, fnSpec.Name.Name)
+
 					// check if we need to clone a method function or a ordinary function
 					receiverSpec := gosyntax.FuncDeclReceiverSpec(fset, fnSpec)
 					if receiverSpec != nil {
@@ -329,7 +397,7 @@ func (g *classMethodGenerator) generateInternal(
 						//
 
 						// find out callee situation
-						clzMethods := generatorCtx.findClassMethods(receiverSpec.TypeDecl, fset, file)
+						clzMethods := generatorCtx.findClassMethods(receiverSpec.TypeDecl, fset, files)
 						v := gosyntax.NewCalleeVisitor(
 							imports,
 							clzMethods,
@@ -337,7 +405,12 @@ func (g *classMethodGenerator) generateInternal(
 							fnSpec.Name.Name,
 						)
 						ast.Walk(v, fnSpec.Body)
-						v.SanitizeCallees(imports)
+						if err := v.SanitizeCallees(g.loader, g.dir, imports); err != nil {
+							g.log.fail(SourceError, "Error in loading callee packages of %s, error: %s\n",
+								fnSpec.Name.Name, err,
+							)
+						}
+						g.summary.FilteredCallees = append(g.summary.FilteredCallees, v.GetFilteredCallees()...)
 
 						overrides := g.getMethodOverrides(file.Name.Name, fnSpec.Name.Name, v, "")
 
@@ -356,13 +429,13 @@ func (g *classMethodGenerator) generateInternal(
 						// pkgs will be in order of how it is defined in "-real,<pkg1>:<pkg2>""
 						autoMockPeer, pkgs := g.getAutoMockCalleeConfig(fnSpec.Name.Name)
 						if autoMockPeer {
-							// peer callee in order of how it is declared in file
-							g.generateMethodPeerCallees(generatorCtx, writer, fset, file, fnSpec, v)
+							// peer callee in order of how it is declared in package files
+							g.generateMethodPeerCallees(generatorCtx, writer, fset, files, fnSpec, v)
 						}
 
 						if len(pkgs) > 0 {
 							// package will be mocked as a struct type, mockedPkgs contains the names of these mocked structs
-							mockedPkgs := g.generateFuncCallees(writer, fset, file, fnSpec, v, pkgs)
+							mockedPkgs := g.generateFuncCallees(generatorCtx, writer, fset, file, fnSpec, v, pkgs)
 							if len(mockedPkgs) > 0 {
 								autoMockPkgs = append(autoMockPkgs, mockedPkgs...)
 							}
@@ -378,7 +451,12 @@ func (g *classMethodGenerator) generateInternal(
 							fnSpec.Name.Name,
 						)
 						ast.Walk(v, fnSpec.Body)
-						v.SanitizeCallees(imports)
+						if err := v.SanitizeCallees(g.loader, g.dir, imports); err != nil {
+							g.log.fail(SourceError, "Error in loading callee packages of %s, error: %s\n",
+								fnSpec.Name.Name, err,
+							)
+						}
+						g.summary.FilteredCallees = append(g.summary.FilteredCallees, v.GetFilteredCallees()...)
 
 						overrides := g.getMethodOverrides(file.Name.Name, fnSpec.Name.Name, v, "m")
 
@@ -397,7 +475,7 @@ func (g *classMethodGenerator) generateInternal(
 
 						if len(pkgs) > 0 {
 							// package will be mocked as a struct type, mockedPkgs contains the names of these mocked structs
-							mockedPkgs := g.generateFuncCallees(writer, fset, file, fnSpec, v, pkgs)
+							mockedPkgs := g.generateFuncCallees(generatorCtx, writer, fset, file, fnSpec, v, pkgs)
 							if len(mockedPkgs) > 0 {
 								autoMockPkgs = append(autoMockPkgs, mockedPkgs...)
 							}
@@ -405,13 +483,9 @@ func (g *classMethodGenerator) generateInternal(
 					}
 				} else if matchType == MATCH_MOCK {
 					// generate mocked method
-					g.composeMock(generatorCtx, writer, fset, fnSpec)
-				}
-			} else {
-				// for any non-function declaration, export only imports
-				if dd, ok := d.(*ast.GenDecl); ok && dd.Tok == token.IMPORT {
-					format.Node(writer, fset, d)
-					writer.Write([]byte("\n\n"))
+					if g.composeMock(generatorCtx, writer, fset, fnSpec) {
+						g.summary.Mocked = append(g.summary.Mocked, fnSpec.Name.Name)
+					}
 				}
 			}
 		}
@@ -424,7 +498,7 @@ func (g *classMethodGenerator) generateMethodPeerCallees(
 	generatorCtx *generatorContext,
 	writer io.Writer,
 	fset *token.FileSet,
-	file *ast.File,
+	files []*ast.File,
 	callerFnSpec *ast.FuncDecl,
 	calleeVisitor *gosyntax.CalleeVisitor,
 ) {
@@ -434,18 +508,23 @@ func (g *classMethodGenerator) generateMethodPeerCallees(
 			// if peer method is not in explicitly specified mocking configuration,
 			// generate it automatically
 			if !slices.Contains(g.methodsToMock, peerMethod) {
-				gosyntax.ForEachFuncDeclInFile(file, func(fnSpec *ast.FuncDecl) {
-					if fnSpec.Name.Name == peerMethod &&
-						gosyntax.ReceiverDeclString(fset, callerFnSpec.Recv) == gosyntax.ReceiverDeclString(fset, fnSpec.Recv) {
-						g.composeMock(generatorCtx, writer, fset, fnSpec)
-					}
-				})
+				for _, file := range files {
+					gosyntax.ForEachFuncDeclInFile(file, func(fnSpec *ast.FuncDecl) {
+						if fnSpec.Name.Name == peerMethod &&
+							gosyntax.SameReceiverType(callerFnSpec.Recv, fnSpec.Recv) {
+							if g.composeMock(generatorCtx, writer, fset, fnSpec) {
+								g.summary.AutoMockedPeers = append(g.summary.AutoMockedPeers, peerMethod)
+							}
+						}
+					})
+				}
 			}
 		}
 	}
 }
 
 func (g *classMethodGenerator) generateFuncCallees(
+	generatorCtx *generatorContext,
 	writer io.Writer,
 	_ *token.FileSet,
 	file *ast.File,
@@ -468,12 +547,18 @@ func (g *classMethodGenerator) generateFuncCallees(
 		}
 
 		for _, callee := range callees {
-			calleeSpec, err := gotype.GetFuncTypeSpec(imports[pkg], callee, g.mockPkgName)
+			calleeSpec, err := g.getFuncTypeSpec(imports[pkg], callee, generatorCtx.imports.Qualifier)
 			if err == nil {
+				if g.summary.AutoMockedCallees == nil {
+					g.summary.AutoMockedCallees = make(map[string][]string)
+				}
+				g.summary.AutoMockedCallees[mockedPkg] = append(g.summary.AutoMockedCallees[mockedPkg], callee)
+
 				gogen.GenerateFuncMock(
 					writer,
-					g.mockPkgName,
-					mockedPkg,
+					g.backend,
+					generatorCtx.imports.Qualifier,
+					&gogen.MockClz{Name: mockedPkg},
 					callee,
 					calleeSpec.FieldInfo,
 					calleeSpec.ReturnInfo,
@@ -487,6 +572,21 @@ func (g *classMethodGenerator) generateFuncCallees(
 	return mockedPkgs
 }
 
+// getFuncTypeSpec returns type spec of function of package pkgPath, which is an
+// import path or . for the package itself
+func (g *classMethodGenerator) getFuncTypeSpec(
+	pkgPath string,
+	fnName string,
+	qualifier types.Qualifier,
+) (*gotype.FuncTypeSpec, error) {
+	pkg, err := g.loader.LoadPackage(g.dir, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return gotype.GetPackageFuncTypeSpec(pkg, fnName, qualifier)
+}
+
 func (g *classMethodGenerator) getMockedPackageClzName(
 	callerPkg string,
 	pkgNameToMock string,
@@ -0,0 +1,63 @@
+package mockcompose
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+
+	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	header = `// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package %s
+`
+)
+
+type parsedFileGenerator interface {
+	generate(writer io.Writer, file *ast.File) error
+}
+
+type parsedPackageGenerator interface {
+	generateViaParsedPackage(writer io.Writer, files []*ast.File) error
+}
+
+type loadedPackageGenerator interface {
+	generateViaLoadedPackage(writer io.Writer, pkg *packages.Package) error
+}
+
+// writeMockFile composes final output of mocking class from generated declarations
+// in file, with the user template if backend is decorated with one
+func writeMockFile(
+	writer io.Writer,
+	backend gogen.Backend,
+	mockPkgName string,
+	imports []gosyntax.ImportSpec,
+	clz *gogen.MockClz,
+	fset *token.FileSet,
+	file *ast.File,
+) error {
+	if tb, ok := backend.(*gogen.TemplateBackend); ok {
+		return tb.WriteFile(writer, &gogen.TemplateData{
+			Package:  mockPkgName,
+			MockName: clz.Name,
+			Header:   fmt.Sprintf(header, mockPkgName),
+			Imports:  imports,
+			Clz:      clz,
+		}, fset, file)
+	}
+
+	fmt.Fprintf(writer, header, mockPkgName)
+
+	gogen.WriteImportDecls(writer, imports)
+
+	backend.WriteClzDecl(writer, clz)
+	backend.WriteClzHelpers(writer, clz)
+
+	gogen.WriteFuncDecls(writer, fset, file)
+	return nil
+}
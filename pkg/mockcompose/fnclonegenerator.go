@@ -1,4 +1,4 @@
-package cmd
+package mockcompose
 
 import (
 	"bytes"
@@ -12,13 +12,14 @@ import (
 
 	"github.com/kelveny/mockcompose/pkg/gogen"
 	"github.com/kelveny/mockcompose/pkg/gosyntax"
-	"github.com/kelveny/mockcompose/pkg/logger"
 )
 
 type functionCloneGenerator struct {
 	mockPkgName    string   // package name that cloned functions reside
 	mockName       string   // name used to form generated file name
 	methodsToClone []string // function names that need to be cloned
+	summary        *Summary
+	log            *reporter
 }
 
 // use compiler to enforce interface compliance
@@ -62,7 +63,7 @@ func (g *functionCloneGenerator) getMethodOverrides(fnName string) map[string]st
 					if len(kv) == 2 {
 						overrides[kv[0]] = kv[1]
 					} else {
-						logger.Log(logger.ERROR, "invalid configuration: -real %s\n", name)
+						g.log.fail(UsageError, "invalid configuration: -real %s\n", name)
 					}
 				}
 				return overrides
@@ -84,8 +85,7 @@ func (g *functionCloneGenerator) generate(
 		// reload generated content to process generated code the second time
 		f, err := parser.ParseFile(fset, "", buf.Bytes(), parser.ParseComments)
 		if err != nil {
-			logger.Log(logger.ERROR, "Internal error: %s\n\n%s\n", err, buf.String())
-			return err
+			return fmt.Errorf("internal error: %s\n\n%s", err, buf.String())
 		}
 
 		// remove unused imports
@@ -120,6 +120,8 @@ func (g *functionCloneGenerator) generateInternal(
 				}
 
 				if matched {
+					g.summary.Cloned = append(g.summary.Cloned, fnSpec.Name.Name)
+
 					overrides := g.getMethodOverrides(fnSpec.Name.Name)
 					gogen.WriteFuncWithLocalOverrides(
 						writer,
@@ -0,0 +1,153 @@
+package mockcompose
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+
+	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/kelveny/mockcompose/pkg/gotype"
+	"golang.org/x/tools/go/packages"
+)
+
+type functionMockGenerator struct {
+	mockPkgName   string   // package name that mocking class resides
+	mockName      string   // the mocking composite class name
+	methodsToMock []string // function names that need to be mocked
+	srcPkg        string
+	backend       gogen.Backend
+	summary       *Summary
+	log           *reporter
+}
+
+// use compiler to enforce interface compliance
+var _ parsedFileGenerator = (*functionMockGenerator)(nil)
+var _ loadedPackageGenerator = (*functionMockGenerator)(nil)
+
+func (g *functionMockGenerator) generate(
+	writer io.Writer,
+	file *ast.File,
+) error {
+	imports := gotype.NewImportTable(g.mockPkgName, "")
+	imports.AddImports(gosyntax.GetFileImports(file))
+
+	var body bytes.Buffer
+	fset := token.NewFileSet()
+
+	// first pass
+	matchCount := 0
+	gosyntax.ForEachFuncDeclInFile(file, func(fnDecl *ast.FuncDecl) {
+		if fnDecl.Recv == nil && g.match(fnDecl.Name.Name) {
+			matchCount++
+			g.summary.Mocked = append(g.summary.Mocked, fnDecl.Name.Name)
+
+			gogen.MockFunc(
+				&body,
+				g.backend,
+				imports.Qualifier,
+				g.getMockClz(),
+				fset,
+				fnDecl.Name.Name,
+				fnDecl.Type.Params,
+				fnDecl.Type.Results,
+				nil,
+			)
+		}
+	})
+
+	// second pass
+	if matchCount > 0 {
+		return g.composeOutput(writer, fset, imports, &body)
+	}
+
+	return nil
+}
+
+func (g *functionMockGenerator) generateViaLoadedPackage(
+	writer io.Writer,
+	pkg *packages.Package,
+) error {
+	imports := gotype.NewImportTable(g.mockPkgName, "")
+	if g.srcPkg != "" {
+		imports.AddImport("", g.srcPkg)
+	}
+
+	var body bytes.Buffer
+	fset := token.NewFileSet()
+
+	// first pass
+	matchCount := 0
+	for _, file := range pkg.Syntax {
+		gosyntax.ForEachFuncDeclInFile(file, func(fnDecl *ast.FuncDecl) {
+			if g.match(fnDecl.Name.Name) {
+				matchCount++
+				g.summary.Mocked = append(g.summary.Mocked, fnDecl.Name.Name)
+
+				// reuse import aliases of the file in which the function is declared
+				imports.AddImports(gosyntax.GetFileImports(file))
+
+				gogen.MockFunc(
+					&body,
+					g.backend,
+					imports.Qualifier,
+					g.getMockClz(),
+					fset,
+					fnDecl.Name.Name,
+					fnDecl.Type.Params,
+					fnDecl.Type.Results,
+					gotype.FindFuncSignature(pkg, fnDecl.Name.Name),
+				)
+			}
+		})
+	}
+
+	// second pass
+	if matchCount > 0 {
+		return g.composeOutput(writer, fset, imports, &body)
+	}
+
+	return nil
+}
+
+func (g *functionMockGenerator) composeOutput(
+	writer io.Writer,
+	fset *token.FileSet,
+	imports *gotype.ImportTable,
+	body *bytes.Buffer,
+) error {
+	var buf bytes.Buffer
+
+	buf.Write([]byte(fmt.Sprintf("package %s\n\n", g.mockPkgName)))
+	gogen.WriteImportDecls(&buf, imports.Imports())
+	buf.Write(body.Bytes())
+
+	// reload generated content to process generated code the second time
+	f, err := parser.ParseFile(fset, "", buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("internal error: %s\n\n%s", err, buf.String())
+	}
+
+	// remove unused imports
+	cleanedImports := gogen.CleanImports(f, g.backend.Imports())
+
+	// compose final output
+	return writeMockFile(writer, g.backend, g.mockPkgName, cleanedImports, g.getMockClz(), fset, f)
+}
+
+func (g *functionMockGenerator) getMockClz() *gogen.MockClz {
+	return &gogen.MockClz{Name: g.mockName}
+}
+
+func (g *functionMockGenerator) match(name string) bool {
+	for _, n := range g.methodsToMock {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
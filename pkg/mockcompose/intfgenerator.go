@@ -0,0 +1,307 @@
+package mockcompose
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+
+	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/goload"
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/kelveny/mockcompose/pkg/gotype"
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"golang.org/x/tools/go/packages"
+)
+
+type interfaceMockGenerator struct {
+	dir    string         // directory of the package
+	loader *goload.Loader // loader of packages
+
+	mockPkgName string // package name that mocking class resides
+	mockName    string // the mocking composite class name
+	intfName    string // interface name
+	srcPkg      string
+	spy         bool // delegate to a real implementation unless expectations are set up
+	backend     gogen.Backend
+	summary     *Summary
+	log         *reporter
+}
+
+// use compiler to enforce interface compliance
+var _ parsedFileGenerator = (*interfaceMockGenerator)(nil)
+var _ loadedPackageGenerator = (*interfaceMockGenerator)(nil)
+
+func (g *interfaceMockGenerator) generate(
+	writer io.Writer,
+	file *ast.File,
+) error {
+	var err error
+	gosyntax.ForEachInterfaceDeclInFile(file,
+		func(name string, typeParams *ast.FieldList, methods []*ast.Field) {
+			if name == g.intfName {
+				imports := gotype.NewImportTable(g.mockPkgName, "")
+				imports.AddImports(gosyntax.GetFileImports(file))
+				if g.srcPkg != "" {
+					imports.AddImport("", g.srcPkg)
+				}
+
+				fset := token.NewFileSet()
+				typeParamNames := gosyntax.TypeParamListNameString(typeParams)
+				err = g.generateInterfaceMock(
+					writer,
+					fset,
+					imports,
+					methods,
+					nil,
+					name,
+					name+typeParamNames,
+					gosyntax.TypeParamListDeclString(fset, typeParams),
+					typeParamNames,
+				)
+			}
+		},
+	)
+	return err
+}
+
+func (g *interfaceMockGenerator) generateViaLoadedPackage(
+	writer io.Writer,
+	pkg *packages.Package,
+) error {
+	var err error
+	for _, file := range pkg.Syntax {
+		gosyntax.ForEachInterfaceDeclInFile(file,
+			func(name string, _ *ast.FieldList, methods []*ast.Field) {
+				if name == g.intfName {
+					// reuse import aliases of the file in which the interface is declared,
+					// other packages are bound on demand when method signatures are rendered
+					imports := gotype.NewImportTable(g.mockPkgName, "")
+					imports.AddImports(gosyntax.GetFileImports(file))
+					if g.srcPkg != "" {
+						imports.AddImport("", g.srcPkg)
+					}
+
+					// type parameters are rendered from type info so that constraints
+					// from other packages are qualified the same way as method signatures
+					typeParams := gotype.FindInterfaceTypeParams(pkg, name)
+					typeParamNames := gotype.RenderTypeParamsNameString(typeParams)
+
+					intfType := name + typeParamNames
+					if g.spy {
+						if qualifier := imports.Qualifier(pkg.Types); qualifier != "" {
+							intfType = qualifier + "." + intfType
+						}
+					}
+
+					var typeParamsDecl string
+					typeParamsDecl, err = gotype.RenderQualifiedTypeParamsDeclString(typeParams, imports.Qualifier)
+					if err != nil {
+						err = g.log.fail(GenerationError, "Error in rendering type parameters of %s, error: %s\n", name, err)
+						return
+					}
+
+					err = g.generateInterfaceMock(
+						writer,
+						token.NewFileSet(),
+						imports,
+						methods,
+						pkg,
+						name,
+						intfType,
+						typeParamsDecl,
+						typeParamNames,
+					)
+				}
+			},
+		)
+	}
+	return err
+}
+
+// generateInterfaceMock generates mock class for the interface, intfType is the interface
+// type referred from generated code, typeParamsDecl and typeParamNames are in format of
+// [K comparable, V any] and [K, V] respectively for generic interfaces, and empty otherwise
+func (g *interfaceMockGenerator) generateInterfaceMock(
+	writer io.Writer,
+	fset *token.FileSet,
+	imports *gotype.ImportTable,
+	methods []*ast.Field,
+	pkg *packages.Package,
+	intfName string,
+	intfType string,
+	typeParamsDecl string,
+	typeParamNames string,
+) error {
+	var buf bytes.Buffer
+
+	clz := g.getMockClz(intfType, typeParamsDecl, typeParamNames)
+	generated, err := g.generateInterfaceMockInternal(&buf, fset, imports, methods, pkg, intfName, clz)
+	if err != nil {
+		return err
+	}
+
+	if generated {
+		// reload generated content to process generated code the second time
+		f, err := parser.ParseFile(fset, "", buf.Bytes(), parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("internal error: %s\n\n%s", err, buf.String())
+		}
+
+		// remove unused imports
+		cleanedImports := gogen.CleanImports(f, g.backend.Imports())
+
+		// compose final output
+		return writeMockFile(writer, g.backend, g.mockPkgName, cleanedImports, clz, fset, f)
+	}
+
+	return nil
+}
+
+func (g *interfaceMockGenerator) generateInterfaceMockInternal(
+	writer io.Writer,
+	fset *token.FileSet,
+	imports *gotype.ImportTable,
+	methods []*ast.Field,
+	pkg *packages.Package,
+	intfName string,
+	clz *gogen.MockClz,
+) (bool, error) {
+	// generate methods first, imports are complete only after all signatures are rendered
+	var body bytes.Buffer
+
+	mockedMethods := make(map[string]bool)
+	for _, method := range methods {
+		if ftype, ok := method.Type.(*ast.FuncType); ok {
+			mockedMethods[method.Names[0].Name] = true
+			g.summary.Mocked = append(g.summary.Mocked, method.Names[0].Name)
+
+			var signature *types.Signature
+			if pkg != nil {
+				signature = gotype.FindInterfaceMethodSignature(
+					pkg,
+					intfName,
+					method.Names[0].Name,
+				)
+			}
+
+			gogen.MockFunc(
+				&body,
+				g.backend,
+				imports.Qualifier,
+				clz,
+				fset,
+				method.Names[0].Name,
+				ftype.Params,
+				ftype.Results,
+				signature,
+			)
+		}
+	}
+
+	if embedded := findEmbeddedInterface(methods); embedded != nil {
+		// methods promoted from embedded interfaces are taken from the complete
+		// method set of the interface, overlapping methods appear only once in it
+		intf := g.findInterfaceType(pkg, intfName)
+		if intf == nil {
+			g.log.LogAt(logger.WARN, fset.Position(embedded.Pos()).String(),
+				"Unable to resolve embedded interfaces of %s\n", intfName)
+		} else {
+			for i := 0; i < intf.NumMethods(); i++ {
+				method := intf.Method(i)
+				if mockedMethods[method.Name()] {
+					continue
+				}
+
+				g.summary.Mocked = append(g.summary.Mocked, method.Name())
+
+				signature := method.Type().(*types.Signature)
+				paramInfos, err := gotype.GetQualifiedFuncParamInfos(signature, imports.Qualifier)
+				if err != nil {
+					return false, g.failRender(fset, embedded, intfName, method.Name(), err)
+				}
+
+				returnInfos, err := gotype.GetQualifiedFuncReturnInfos(signature, imports.Qualifier)
+				if err != nil {
+					return false, g.failRender(fset, embedded, intfName, method.Name(), err)
+				}
+
+				gogen.GenerateFuncMock(
+					&body,
+					g.backend,
+					imports.Qualifier,
+					clz,
+					method.Name(),
+					paramInfos,
+					returnInfos,
+					nil,
+				)
+			}
+		}
+	}
+
+	writer.Write([]byte(fmt.Sprintf("package %s\n\n", g.mockPkgName)))
+	gogen.WriteImportDecls(writer, imports.Imports())
+	writer.Write(body.Bytes())
+
+	return true, nil
+}
+
+// failRender reports a promoted method of interface that can not be rendered at
+// the embedded interface it is promoted from
+func (g *interfaceMockGenerator) failRender(
+	fset *token.FileSet,
+	embedded ast.Node,
+	intfName string,
+	method string,
+	err error,
+) error {
+	return g.log.failAt(GenerationError, fset.Position(embedded.Pos()).String(),
+		"Error in rendering method %s of %s, error: %s\n", method, intfName, err)
+}
+
+func (g *interfaceMockGenerator) getMockClz(
+	intfType string,
+	typeParamsDecl string,
+	typeParamNames string,
+) *gogen.MockClz {
+	clz := &gogen.MockClz{
+		Name:           g.mockName,
+		TypeParamsDecl: typeParamsDecl,
+		TypeParamNames: typeParamNames,
+	}
+	if g.spy {
+		clz.Delegate = intfType
+	}
+	return clz
+}
+
+// findInterfaceType finds interface type from loaded package, when generating from
+// parsed file, package in the directory is loaded for type information
+func (g *interfaceMockGenerator) findInterfaceType(
+	pkg *packages.Package,
+	intfName string,
+) *types.Interface {
+	if pkg == nil {
+		var err error
+		if pkg, err = g.loader.LoadPackage(g.dir, "."); err != nil {
+			return nil
+		}
+	}
+
+	return gotype.FindInterface(pkg, intfName)
+}
+
+// findEmbeddedInterface returns the first embedded element of interface declaration,
+// or nil if there is none
+func findEmbeddedInterface(methods []*ast.Field) *ast.Field {
+	for _, method := range methods {
+		if _, ok := method.Type.(*ast.FuncType); !ok {
+			return method
+		}
+	}
+	return nil
+}
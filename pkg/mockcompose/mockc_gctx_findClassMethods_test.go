@@ -1,6 +1,6 @@
 // CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
 // THIS FILE SHOULD NOT BE EDITED BY HAND
-package cmd
+package mockcompose
 
 import (
 	"go/ast"
@@ -21,26 +21,52 @@ type mock_gctx_findClassMethods_findClassMethods_gosyntax struct {
 	mock.Mock
 }
 
-func (c *gctx_findClassMethods) findClassMethods(clzTypeDeclString string, fset *token.FileSet, f *ast.File) map[string]*gosyntaxtyp.ReceiverSpec {
+func newGctx_findClassMethods(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src generatorContext) *gctx_findClassMethods {
+	m := &gctx_findClassMethods{generatorContext: src}
+	m.Mock.Test(t)
+	m.mock_gctx_findClassMethods_findClassMethods_gosyntax.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_gctx_findClassMethods_findClassMethods_gosyntax.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (c *gctx_findClassMethods) findClassMethods(clzTypeDeclString string, fset *token.FileSet, files []*ast.File) map[string]*gosyntaxtyp.ReceiverSpec {
 	gosyntax := &c.mock_gctx_findClassMethods_findClassMethods_gosyntax
 
 	if c.clzMethods == nil {
 		c.clzMethods = make(map[string]map[string]*gosyntaxtyp.ReceiverSpec)
 	}
 	if _, ok := c.clzMethods[clzTypeDeclString]; !ok {
-		c.clzMethods[clzTypeDeclString] = gosyntax.FindClassMethods(clzTypeDeclString, fset, f)
+		c.clzMethods[clzTypeDeclString] = gosyntax.FindClassMethods(clzTypeDeclString, fset, files...)
 	}
 	return c.clzMethods[clzTypeDeclString]
 }
 
-func (m *mock_gctx_findClassMethods_findClassMethods_gosyntax) FindClassMethods(clzTypeDeclString string, fset *token.FileSet, f *ast.File) map[string]*gosyntax.ReceiverSpec {
+func (m *mock_gctx_findClassMethods_findClassMethods_gosyntax) FindClassMethods(clzTypeDeclString string, fset *token.FileSet, files ...*ast.File) map[string]*gosyntax.ReceiverSpec {
+
+	_mc_args := make([]interface{}, 0, 2+len(files))
+
+	_mc_args = append(_mc_args, clzTypeDeclString)
+
+	_mc_args = append(_mc_args, fset)
+
+	for _, _va := range files {
+		_mc_args = append(_mc_args, _va)
+	}
 
-	_mc_ret := m.Called(clzTypeDeclString, fset, f)
+	_mc_ret := m.Called(_mc_args...)
 
 	var _r0 map[string]*gosyntax.ReceiverSpec
 
-	if _rfn, ok := _mc_ret.Get(0).(func(string, *token.FileSet, *ast.File) map[string]*gosyntax.ReceiverSpec); ok {
-		_r0 = _rfn(clzTypeDeclString, fset, f)
+	if _rfn, ok := _mc_ret.Get(0).(func(string, *token.FileSet, ...*ast.File) map[string]*gosyntax.ReceiverSpec); ok {
+		_r0 = _rfn(clzTypeDeclString, fset, files...)
 	} else {
 		if _mc_ret.Get(0) != nil {
 			_r0 = _mc_ret.Get(0).(map[string]*gosyntax.ReceiverSpec)
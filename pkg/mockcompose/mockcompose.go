@@ -0,0 +1,422 @@
+package mockcompose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kelveny/mockcompose/pkg/gofile"
+	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/goload"
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/kelveny/mockcompose/pkg/logger"
+)
+
+// Options describes code to generate, it is also an entry of YAML configuration
+type Options struct {
+	MockName string `yaml:"name" json:"name"`
+	MockPkg  string `yaml:"mockPkg" json:"mockPkg,omitempty"`
+	ClzName  string `yaml:"className" json:"className,omitempty"`
+	IntfName string `yaml:"interfaceName" json:"interfaceName,omitempty"`
+	SrcPkg   string `yaml:"sourcePkg" json:"sourcePkg,omitempty"`
+	TestOnly bool   `yaml:"testOnly" json:"testOnly"`
+	Expecter bool   `yaml:"expecter" json:"expecter,omitempty"`
+	Spy      bool   `yaml:"spy" json:"spy,omitempty"`
+	Backend  string `yaml:"backend" json:"backend,omitempty"`
+	Template string `yaml:"template" json:"template,omitempty"`
+	Output   string `yaml:"output" json:"output,omitempty"`     // path of the generated file or the directory it resides
+	FileName string `yaml:"fileName" json:"fileName,omitempty"` // text/template of generated file name, see DefaultFileName
+
+	// For example content: "functionThatUsesMultileGlobalFunctions,fmt=fmtMock:json=jsonMock",
+	// it means to use "fmtMock" class as package "fmt", "jsonMock" class as package "json" in the cloned function
+	//
+	// For example content: "functionThatUsesMultileGlobalFunctions,this:.:fmt:json",
+	// it means to mock peer callee methods (this as psudo package name), auto generated callee packages for "." package, "fmt" amd "json" package
+
+	MethodsToClone []string `yaml:"real,flow" json:"real,omitempty"`
+
+	MethodsToMock []string `yaml:"mock,flow" json:"mock,omitempty"`
+
+	// directory of the package to generate against, relative to current working
+	// directory unless it is absolute, empty for current working directory. Output
+	// and Template are relative to Dir as well
+	Dir string `yaml:"-" json:"-"`
+
+	// packages are loaded with Loader if it is set, otherwise with a loader of the
+	// call, setting the same loader shares loaded packages across calls
+	Loader *goload.Loader `yaml:"-" json:"-"`
+
+	// messages of generation are logged with Logger if it is set
+	Logger *logger.Logger `yaml:"-" json:"-"`
+}
+
+// GeneratedFile is a formatted file generated by Generate
+type GeneratedFile struct {
+	Path    string // relative to current working directory unless Dir or output is an absolute path
+	Content []byte
+
+	Diagnostics []Diagnostic // warnings and errors reported during generation
+	Summary     Summary      // what is generated into the file
+}
+
+// Summary describes code generated for an entry
+type Summary struct {
+	Cloned            []string                  `json:"cloned,omitempty"`            // cloned methods and functions
+	Mocked            []string                  `json:"mocked,omitempty"`            // mocked methods and functions
+	AutoMockedPeers   []string                  `json:"autoMockedPeers,omitempty"`   // peer methods mocked for cloned methods
+	AutoMockedCallees map[string][]string       `json:"autoMockedCallees,omitempty"` // mocking class of a package -> mocked functions
+	FilteredCallees   []gosyntax.FilteredCallee `json:"filteredCallees,omitempty"`   // callees of cloned code that are not mockable
+}
+
+// Kind classifies errors of generation, a kind takes precedence over the ones
+// declared before it
+type Kind int
+
+const (
+	GenerationError Kind = iota + 1 // code can not be generated, i.e., nothing matched
+	SourceError                     // source code can not be parsed or type checked
+	UsageError                      // options are invalid
+)
+
+func (k Kind) String() string {
+	switch k {
+	case GenerationError:
+		return "generation error"
+	case SourceError:
+		return "source error"
+	case UsageError:
+		return "usage error"
+	}
+	return "none"
+}
+
+// Diagnostic is a warning or an error reported during generation
+type Diagnostic struct {
+	Level   logger.MessageType // logger.WARN or logger.ERROR
+	Kind    Kind               // kind of the error, zero for warnings
+	Message string
+	Pos     string // file:line:column of source code the diagnostic is about, if known
+	Option  string // option the diagnostic is about, i.e., real[1], if known
+}
+
+// Error is returned by Generate if generation fails
+type Error struct {
+	Diagnostics []Diagnostic
+}
+
+// Kind returns the kind that takes precedence among errors of generation
+func (e *Error) Kind() Kind {
+	var kind Kind
+	for _, d := range e.Diagnostics {
+		if d.Level == logger.ERROR && d.Kind > kind {
+			kind = d.Kind
+		}
+	}
+	return kind
+}
+
+func (e *Error) Error() string {
+	var msgs []string
+	for _, d := range e.Diagnostics {
+		if d.Level == logger.ERROR {
+			if d.Pos != "" {
+				msgs = append(msgs, d.Pos+": "+d.Message)
+			} else {
+				msgs = append(msgs, d.Message)
+			}
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Generate generates code described by options against package in options.Dir,
+// nothing is written to file system. Files generated before a failure are returned
+// together with the error, which is an *Error in case of generation failures
+func Generate(ctx context.Context, options Options) ([]GeneratedFile, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	options.useLoader()
+	log := &reporter{logger: options.Logger}
+	out := &collector{}
+	summary := &Summary{}
+
+	err := generate(ctx, &options, out, summary, log)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return out.files, ctxErr
+	}
+
+	for i := range out.files {
+		out.files[i].Diagnostics = log.diagnostics
+		out.files[i].Summary = *summary
+	}
+
+	if err != nil || log.failed() {
+		return out.files, &Error{Diagnostics: log.diagnostics}
+	}
+	return out.files, nil
+}
+
+// generate generates code described by options, it is an error if nothing matches
+func generate(ctx context.Context, options *Options, out *collector, summary *Summary, log *reporter) error {
+	if options.MockName == "" {
+		return log.fail(UsageError, "name of the generated class is not specified\n")
+	}
+
+	if options.MockPkg == "" {
+		if options.MockPkg = gofile.DeriveDirPackage(options.Dir, false); options.MockPkg == "" {
+			return log.fail(UsageError, "unable to derive package name of the generated class\n")
+		}
+	}
+
+	if err := dispatch(ctx, options, out, summary, log); err != nil {
+		return err
+	}
+
+	if len(out.files) == 0 && !log.failed() {
+		return log.fail(GenerationError, "nothing matched %s\n", options.target())
+	}
+	return nil
+}
+
+// dispatch runs generator of the matching kind
+func dispatch(ctx context.Context, options *Options, out *collector, summary *Summary, log *reporter) error {
+	var g parsedFileGenerator
+
+	backend, err := gogen.NewBackend(options.Backend, gogen.BackendOptions{
+		Expecter: options.Expecter,
+		Spy:      options.Spy,
+	})
+	if err != nil {
+		return log.fail(UsageError, "%s\n", err)
+	}
+
+	if _, err := options.fileName(); err != nil {
+		return log.fail(UsageError, "invalid fileName template: %s\n", err)
+	}
+
+	if options.Template != "" {
+		tmpl, err := gogen.ParseTemplateFile(options.templateFileName())
+		if err != nil {
+			return log.fail(UsageError, "Failed to load template: %s\n", err)
+		}
+		backend = gogen.NewTemplateBackend(backend, tmpl)
+	}
+
+	if options.Spy {
+		if options.IntfName == "" {
+			return log.fail(UsageError, "option -spy only applies to interface mocks\n")
+		}
+
+		if options.Backend == "gomock" {
+			return log.fail(UsageError, "option -spy is not supported by gomock backend\n")
+		}
+	}
+
+	if options.Expecter && (options.Backend == "gomock" || options.Backend == "fake") {
+		return log.fail(UsageError, "option -expecter is not supported by %s backend\n", options.Backend)
+	}
+
+	for _, spec := range options.MethodsToClone {
+		if _, err := ParseClosure(spec); err != nil {
+			return log.fail(UsageError, "invalid configuration: -real %s\n", err)
+		}
+	}
+
+	if options.ClzName != "" || len(options.MethodsToClone) > 0 {
+		if len(options.MethodsToClone) == 0 {
+			return log.fail(UsageError, "Please specify at least one real method name with -real option\n")
+		}
+
+		g = &classMethodGenerator{
+			dir:            options.Dir,
+			loader:         options.Loader,
+			clzName:        options.ClzName,
+			mockPkgName:    options.MockPkg,
+			mockName:       options.MockName,
+			methodsToClone: options.MethodsToClone,
+			methodsToMock:  options.MethodsToMock,
+			backend:        backend,
+			summary:        summary,
+			log:            log,
+		}
+
+		// class methods may spread across multiple files of the package
+		scanCWDPackageToGenerate(ctx, g.(parsedPackageGenerator), options, out, log)
+		return nil
+	} else if options.IntfName != "" {
+		g = &interfaceMockGenerator{
+			dir:         options.Dir,
+			loader:      options.Loader,
+			mockPkgName: options.MockPkg,
+			mockName:    options.MockName,
+			intfName:    options.IntfName,
+			srcPkg:      options.SrcPkg,
+			spy:         options.Spy,
+			backend:     backend,
+			summary:     summary,
+			log:         log,
+		}
+
+		if options.SrcPkg != "" {
+			scanPackageToGenerate(ctx, g.(loadedPackageGenerator), options, out, log)
+			return nil
+		}
+	} else {
+		if len(options.MethodsToMock) == 0 && len(options.MethodsToClone) == 0 {
+			return log.fail(UsageError, "no function to mock or clone\n")
+		}
+
+		if len(options.MethodsToMock) > 0 && len(options.MethodsToClone) > 0 {
+			return log.fail(UsageError, "option -real and option -mock are exclusive in function clone generation\n")
+		}
+
+		if len(options.MethodsToClone) > 0 {
+			// pure function clone is now deprecated
+			if options.SrcPkg != "" {
+				log.Log(logger.PROMPT,
+					"No source package support in function clone generation, ignore source package %s\n",
+					options.SrcPkg)
+			}
+			g = &functionCloneGenerator{
+				mockPkgName:    options.MockPkg,
+				mockName:       options.MockName,
+				methodsToClone: options.MethodsToClone,
+				summary:        summary,
+				log:            log,
+			}
+		} else {
+			g = &functionMockGenerator{
+				mockPkgName:   options.MockPkg,
+				mockName:      options.MockName,
+				methodsToMock: options.MethodsToMock,
+				srcPkg:        options.SrcPkg,
+				backend:       backend,
+				summary:       summary,
+				log:           log,
+			}
+
+			if options.SrcPkg != "" {
+				scanPackageToGenerate(ctx, g.(loadedPackageGenerator), options, out, log)
+				return nil
+			}
+		}
+	}
+
+	scanCWDToGenerate(ctx, g, options, out, log)
+	return nil
+}
+
+// useLoader sets a loader of its own to options if no loader is set
+func (options *Options) useLoader() {
+	if options.Loader == nil {
+		options.Loader = goload.NewLoader(nil)
+		options.Loader.SetLogger(options.Logger)
+	}
+}
+
+// target describes what options generate against
+func (options *Options) target() string {
+	var target string
+	switch {
+	case options.ClzName != "":
+		target = "class " + options.ClzName
+	case options.IntfName != "":
+		target = "interface " + options.IntfName
+	case len(options.MethodsToMock) > 0:
+		target = "functions " + strings.Join(options.MethodsToMock, ", ")
+	default:
+		target = "functions " + strings.Join(options.MethodsToClone, ", ")
+	}
+
+	if options.SrcPkg != "" && options.ClzName == "" && len(options.MethodsToClone) == 0 {
+		return target + " in package " + options.SrcPkg
+	}
+	return target + " in current working directory"
+}
+
+// reporter records diagnostics of a generation and forwards messages to logger
+type reporter struct {
+	logger      *logger.Logger
+	diagnostics []Diagnostic
+}
+
+// Log logs a message, errors are recorded as generation errors
+func (r *reporter) Log(msgType logger.MessageType, format string, args ...interface{}) {
+	var kind Kind
+	if msgType == logger.ERROR {
+		kind = GenerationError
+	}
+	r.report(msgType, kind, "", format, args...)
+}
+
+// LogAt logs a message about source code at pos
+func (r *reporter) LogAt(msgType logger.MessageType, pos string, format string, args ...interface{}) {
+	var kind Kind
+	if msgType == logger.ERROR {
+		kind = GenerationError
+	}
+	r.report(msgType, kind, pos, format, args...)
+}
+
+// fail logs an error of kind and returns it
+func (r *reporter) fail(kind Kind, format string, args ...interface{}) error {
+	return r.failAt(kind, "", format, args...)
+}
+
+// failAt logs an error of kind about source code at pos and returns it
+func (r *reporter) failAt(kind Kind, pos string, format string, args ...interface{}) error {
+	r.report(logger.ERROR, kind, pos, format, args...)
+
+	msg := strings.TrimSpace(fmt.Sprintf(format, args...))
+	if pos != "" {
+		msg = pos + ": " + msg
+	}
+	return errors.New(msg)
+}
+
+func (r *reporter) report(msgType logger.MessageType, kind Kind, pos string, format string, args ...interface{}) {
+	if msgType >= logger.WARN {
+		r.diagnostics = append(r.diagnostics, Diagnostic{
+			Level:   msgType,
+			Kind:    kind,
+			Message: strings.TrimSpace(fmt.Sprintf(format, args...)),
+			Pos:     pos,
+		})
+	}
+
+	if r.logger != nil {
+		r.logger.LogAt(msgType, pos, format, args...)
+	}
+}
+
+func (r *reporter) failed() bool {
+	for _, d := range r.diagnostics {
+		if d.Level == logger.ERROR {
+			return true
+		}
+	}
+	return false
+}
+
+// collector collects generated files in order, a file that is generated more than
+// once keeps its latest content
+type collector struct {
+	files []GeneratedFile
+}
+
+func (c *collector) write(fileName string, content []byte) {
+	for i := range c.files {
+		if c.files[i].Path == fileName {
+			c.files[i].Content = content
+			return
+		}
+	}
+
+	c.files = append(c.files, GeneratedFile{
+		Path:    fileName,
+		Content: content,
+	})
+}
@@ -0,0 +1,268 @@
+package mockcompose
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kelveny/mockcompose/pkg/goload"
+	"github.com/kelveny/mockcompose/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	assert := require.New(t)
+
+	files, err := Generate(context.Background(), Options{
+		MockName: "mockFoo",
+		MockPkg:  "mockintf",
+		IntfName: "Foo",
+		SrcPkg:   "github.com/kelveny/mockcompose/test/foo",
+		TestOnly: true,
+	})
+	assert.NoError(err)
+	assert.Len(files, 1)
+	assert.Equal("mockc_mockFoo_test.go", files[0].Path)
+
+	// same as the file generated by go generate
+	expected, err := os.ReadFile("../../test/mockintf/mockc_mockFoo_test.go")
+	assert.NoError(err)
+	assert.Equal(string(expected), string(files[0].Content))
+
+	// nothing is written
+	_, err = os.Stat(files[0].Path)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestGenerate_error(t *testing.T) {
+	assert := require.New(t)
+
+	files, err := Generate(context.Background(), Options{
+		MockName: "mockFoo",
+		IntfName: "Foo",
+		SrcPkg:   "github.com/kelveny/mockcompose/test/foo",
+		Spy:      true,
+		Backend:  "gomock",
+	})
+	assert.Empty(files)
+
+	var genErr *Error
+	assert.True(errors.As(err, &genErr))
+	assert.Equal([]Diagnostic{
+		{Level: logger.ERROR, Kind: UsageError, Message: "option -spy is not supported by gomock backend"},
+	}, genErr.Diagnostics)
+	assert.Equal("option -spy is not supported by gomock backend", err.Error())
+	assert.Equal(UsageError, genErr.Kind())
+}
+
+func TestGenerate_expecterError(t *testing.T) {
+	assert := require.New(t)
+
+	for _, backend := range []string{"gomock", "fake"} {
+		files, err := Generate(context.Background(), Options{
+			MockName: "mockFoo",
+			IntfName: "Foo",
+			SrcPkg:   "github.com/kelveny/mockcompose/test/foo",
+			Expecter: true,
+			Backend:  backend,
+		})
+		assert.Empty(files)
+
+		var genErr *Error
+		assert.True(errors.As(err, &genErr))
+		assert.Equal(UsageError, genErr.Kind())
+		assert.Equal("option -expecter is not supported by "+backend+" backend", err.Error())
+	}
+}
+
+func TestGenerate_nothingMatched(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := Generate(context.Background(), Options{
+		MockName: "mockFoo",
+		IntfName: "Fooo",
+		SrcPkg:   "github.com/kelveny/mockcompose/test/foo",
+	})
+
+	var genErr *Error
+	assert.True(errors.As(err, &genErr))
+	assert.Equal(GenerationError, genErr.Kind())
+	assert.Equal("nothing matched interface Fooo in package github.com/kelveny/mockcompose/test/foo", err.Error())
+}
+
+func TestGenerate_sourceError(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := Generate(context.Background(), Options{
+		MockName: "mockFoo",
+		IntfName: "Foo",
+		SrcPkg:   "github.com/kelveny/mockcompose/test/nonexistent",
+	})
+
+	var genErr *Error
+	assert.True(errors.As(err, &genErr))
+	assert.Equal(SourceError, genErr.Kind())
+}
+
+func TestGenerate_canceled(t *testing.T) {
+	assert := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Generate(ctx, Options{MockName: "mockFoo", IntfName: "Foo"})
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestOptions_OutputFileName(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+
+	assert.Equal("mockc_mockFoo_test.go", (&Options{MockName: "mockFoo", TestOnly: true}).OutputFileName())
+	assert.Equal("mockc_mockFoo.go", (&Options{MockName: "mockFoo"}).OutputFileName())
+	assert.Equal(filepath.Join(dir, "mockc_mockFoo.go"), (&Options{MockName: "mockFoo", Output: dir}).OutputFileName())
+	assert.Equal(filepath.Join("mocks", "mockc_mockFoo.go"), (&Options{MockName: "mockFoo", Output: "mocks/"}).OutputFileName())
+	assert.Equal(filepath.Join("mocks", "foo.go"), (&Options{MockName: "mockFoo", Output: "./mocks/foo.go"}).OutputFileName())
+
+	options := &Options{MockName: "mockFoo", MockPkg: "foo", TestOnly: true, FileName: "{{.Package}}_{{.Name}}{{if .TestOnly}}_test{{end}}.go"}
+	assert.Equal("foo_mockFoo_test.go", options.OutputFileName())
+	options.Output = "mocks/"
+	assert.Equal(filepath.Join("mocks", "foo_mockFoo_test.go"), options.OutputFileName())
+
+	options = &Options{MockName: "mockFoo", TestOnly: true, FileName: DefaultFileName}
+	assert.Equal("mockc_mockFoo_test.go", options.OutputFileName())
+
+	// output is relative to Dir
+	options = &Options{MockName: "mockFoo", Dir: "foo"}
+	assert.Equal(filepath.Join("foo", "mockc_mockFoo.go"), options.OutputFileName())
+	options.Output = "mocks/"
+	assert.Equal(filepath.Join("foo", "mocks", "mockc_mockFoo.go"), options.OutputFileName())
+	options.Output = dir
+	assert.Equal(filepath.Join(dir, "mockc_mockFoo.go"), options.OutputFileName())
+
+	_, err := Generate(context.Background(), Options{MockName: "mockFoo", IntfName: "Foo", FileName: "{{.Nme}}.go"})
+	var genErr *Error
+	assert.ErrorAs(err, &genErr)
+	assert.Equal(UsageError, genErr.Kind())
+}
+
+func TestGenerate_summary(t *testing.T) {
+	assert := require.New(t)
+
+	files, err := Generate(context.Background(), Options{
+		Dir:            "../../test/gomockgen",
+		MockName:       "mockGreeter",
+		ClzName:        "Greeter",
+		MethodsToClone: []string{"Greet,this:fmt"},
+		Backend:        "gomock",
+		TestOnly:       true,
+	})
+	assert.NoError(err)
+	assert.Len(files, 1)
+	assert.Equal(filepath.Join("../../test/gomockgen", "mockc_mockGreeter_test.go"), files[0].Path)
+
+	expected, err := os.ReadFile(files[0].Path)
+	assert.NoError(err)
+	assert.Equal(string(expected), string(files[0].Content))
+
+	assert.Equal(Summary{
+		Cloned:          []string{"Greet"},
+		AutoMockedPeers: []string{"known"},
+		AutoMockedCallees: map[string][]string{
+			"mock_mockGreeter_Greet_fmt": {"Sprintf"},
+		},
+	}, files[0].Summary)
+}
+
+func TestGenerate_dir(t *testing.T) {
+	assert := require.New(t)
+
+	greeter := Options{
+		Dir:            "../../test/gomockgen",
+		MockName:       "mockGreeter",
+		ClzName:        "Greeter",
+		MethodsToClone: []string{"Greet,this:fmt"},
+		Backend:        "gomock",
+		TestOnly:       true,
+	}
+	race := Options{
+		Dir:            "../../test/race",
+		MockName:       "raceMock",
+		ClzName:        "race",
+		MethodsToClone: []string{"RaceRun"},
+		MethodsToMock:  []string{"WorkRun"},
+		TestOnly:       true,
+	}
+
+	// packages loaded by the shared loader are not mixed up across directories
+	loader := goload.NewLoader(nil)
+	for _, options := range []Options{greeter, race, greeter} {
+		options.Loader = loader
+
+		files, err := Generate(context.Background(), options)
+		assert.NoError(err)
+		assert.Len(files, 1)
+
+		expected, err := os.ReadFile(files[0].Path)
+		assert.NoError(err)
+		assert.Equal(string(expected), string(files[0].Content))
+	}
+}
+
+func TestGenerate_buildConstraints(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	other := "windows"
+	if runtime.GOOS == "windows" {
+		other = "linux"
+	}
+
+	for name, content := range map[string]string{
+		"go.mod": "module example.com/foo\n",
+		"foo.go": `package foo
+
+type Foo struct{}
+
+func (f *Foo) Bar() string {
+	return f.name()
+}
+
+func (f *Foo) name() string { return "foo" }
+`,
+		"foo_" + other + ".go": `package foo
+
+func (f *Foo) name() string { return "` + other + `" }
+`,
+		"gen.go": `//go:build ignore
+
+package foo
+
+func (f *Foo) Bar() string { return "" }
+`,
+	} {
+		assert.NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	files, err := Generate(context.Background(), Options{
+		Dir:            dir,
+		MockName:       "mockFoo",
+		MockPkg:        "foo",
+		ClzName:        "Foo",
+		MethodsToClone: []string{"Bar"},
+		MethodsToMock:  []string{"name"},
+		TestOnly:       true,
+	})
+	assert.NoError(err)
+	assert.Len(files, 1)
+
+	// files excluded by build constraints are not part of the class
+	content := string(files[0].Content)
+	assert.Equal(1, strings.Count(content, "func (f *mockFoo) Bar() string"))
+	assert.Equal(1, strings.Count(content, "func (m *mockFoo) name() string"))
+}
@@ -0,0 +1,366 @@
+package mockcompose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/kelveny/mockcompose/pkg/gofile"
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/kelveny/mockcompose/pkg/logger"
+)
+
+func scanPackageToGenerate(
+	ctx context.Context,
+	g loadedPackageGenerator,
+	options *Options,
+	out *collector,
+	log *reporter,
+) {
+	pkg, err := options.Loader.LoadPackage(options.Dir, options.SrcPkg)
+	if err != nil {
+		log.fail(SourceError, "Error in loading package %s, error: %s\n",
+			options.SrcPkg, err,
+		)
+		return
+	}
+
+	log.Log(logger.PROMPT, "Scan package %s...\n", options.SrcPkg)
+
+	if len(pkg.Syntax) == 0 {
+		for _, err := range pkg.Errors {
+			log.failAt(SourceError, err.Pos, "%s error: %s\n",
+				pkg.ID, err.Msg,
+			)
+		}
+		return
+	}
+
+	generateOutputFile(options.OutputFileName(), func(output io.Writer) error {
+		return g.generateViaLoadedPackage(output, pkg)
+	}, out, log)
+
+	log.Log(logger.PROMPT, "Done scan with package %s\n\n", options.SrcPkg)
+}
+
+// scan directory of options, current working directory by default
+func scanCWDToGenerate(
+	ctx context.Context,
+	g parsedFileGenerator,
+	options *Options,
+	out *collector,
+	log *reporter,
+) {
+	pkgDir, err := filepath.Abs(options.Dir)
+	log.Log(logger.VERBOSE, "Check directory %s for code generation\n", pkgDir)
+	if err != nil {
+		log.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return
+	}
+
+	if dir, err := os.Stat(pkgDir); err == nil && dir.IsDir() {
+		fileInfos, err := ioutil.ReadDir(pkgDir)
+		if err != nil {
+			log.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+			return
+		}
+
+		for _, fileInfo := range fileInfos {
+			if ctx.Err() != nil {
+				return
+			}
+
+			scanFileToGenerate(g, options, pkgDir, fileInfo, out, log)
+		}
+	}
+}
+
+// scan directory of options as a whole package
+func scanCWDPackageToGenerate(
+	ctx context.Context,
+	g parsedPackageGenerator,
+	options *Options,
+	out *collector,
+	log *reporter,
+) {
+	pkgDir, err := filepath.Abs(options.Dir)
+	log.Log(logger.VERBOSE, "Check directory %s for code generation\n", pkgDir)
+	if err != nil {
+		log.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return
+	}
+
+	fileInfos, err := ioutil.ReadDir(pkgDir)
+	if err != nil {
+		log.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+		return
+	}
+
+	log.Log(logger.PROMPT, "Scan package in %s...\n", pkgDir)
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, fileInfo := range fileInfos {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if !isPackageFile(pkgDir, fileInfo, log) {
+			continue
+		}
+
+		file, err := parser.ParseFile(
+			fset,
+			filepath.Join(pkgDir, fileInfo.Name()),
+			nil,
+			parser.ParseComments)
+
+		if err != nil {
+			log.fail(SourceError, "Error in parsing %s, error: %s\n",
+				filepath.Join(pkgDir, fileInfo.Name()), err,
+			)
+			return
+		}
+		files = append(files, file)
+	}
+
+	files = filterPackageFiles(files, options.ClzName, log)
+
+	generateOutputFile(options.OutputFileName(), func(output io.Writer) error {
+		return g.generateViaParsedPackage(output, files)
+	}, out, log)
+
+	log.Log(logger.PROMPT, "Done scan with package in %s\n\n", pkgDir)
+}
+
+// isPackageFile tells if file in pkgDir is a non-test Go file of the package that
+// is built in current build context, files excluded by build constraints, i.e.,
+// GOOS/GOARCH file name suffixes or //go:build ignore, are skipped
+func isPackageFile(pkgDir string, fileInfo os.FileInfo, log *reporter) bool {
+	if fileInfo.IsDir() || !strings.HasSuffix(fileInfo.Name(), ".go") ||
+		strings.HasSuffix(fileInfo.Name(), "_test.go") {
+		return false
+	}
+
+	match, err := build.Default.MatchFile(pkgDir, fileInfo.Name())
+	if err != nil {
+		log.Log(logger.WARN, "Error in checking build constraints of %s, error: %s\n",
+			filepath.Join(pkgDir, fileInfo.Name()), err,
+		)
+		return false
+	}
+
+	if !match {
+		log.Log(logger.VERBOSE, "Skip file %s excluded by build constraints\n", fileInfo.Name())
+	}
+	return match
+}
+
+// filterPackageFiles keeps files of the package in which the class is declared,
+// files of other packages in the same directory (i.e., package main with build
+// constraints) are skipped
+func filterPackageFiles(files []*ast.File, clzName string, log *reporter) []*ast.File {
+	if len(files) == 0 {
+		return files
+	}
+
+	pkgName := files[0].Name.Name
+	for _, file := range files {
+		if clzName != "" && gosyntax.FindTypeSpec(file, clzName) != nil {
+			pkgName = file.Name.Name
+			break
+		}
+	}
+
+	var pkgFiles []*ast.File
+	for _, file := range files {
+		if file.Name.Name == pkgName {
+			pkgFiles = append(pkgFiles, file)
+		} else {
+			log.Log(logger.VERBOSE, "Skip file of package %s\n", file.Name.Name)
+		}
+	}
+	return pkgFiles
+}
+
+// not in use
+func scanGoPathToGenerate(
+	g parsedFileGenerator,
+	options *Options,
+	out *collector,
+	log *reporter,
+) {
+	// iterate candidates from package directory
+	gopathConfig := gofile.GetGoPathConfig()
+
+	for _, gopath := range strings.Split(gopathConfig, string(filepath.ListSeparator)) {
+		// support scanning of subfolder src/ and pkg/
+		for _, subFolder := range []string{"src", "pkg"} {
+			pkgDir, err := filepath.Abs(path.Join(gopath, subFolder, options.MockPkg))
+			log.Log(logger.VERBOSE, "Check directory %s for code generation\n", pkgDir)
+			if err != nil {
+				log.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+				return
+			}
+
+			if dir, err := os.Stat(pkgDir); err == nil && dir.IsDir() {
+				fileInfos, err := ioutil.ReadDir(pkgDir)
+				if err != nil {
+					log.Log(logger.ERROR, "Error in accessing file system. error: %s\n", err)
+					return
+				}
+
+				for _, fileInfo := range fileInfos {
+					scanFileToGenerate(g, options, pkgDir, fileInfo, out, log)
+				}
+			}
+		}
+	}
+}
+
+func scanFileToGenerate(
+	g parsedFileGenerator,
+	options *Options,
+	pkgDir string,
+	fileInfo os.FileInfo,
+	out *collector,
+	log *reporter,
+) {
+	if isPackageFile(pkgDir, fileInfo, log) {
+
+		log.Log(logger.PROMPT, "Scan %s...\n", filepath.Join(pkgDir, fileInfo.Name()))
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(
+			fset,
+			filepath.Join(pkgDir, fileInfo.Name()),
+			nil,
+			parser.ParseComments)
+
+		if err != nil {
+			log.fail(SourceError, "Error in parsing %s, error: %s\n",
+				filepath.Join(pkgDir, fileInfo.Name()), err,
+			)
+			return
+		}
+
+		generateOutputFile(options.OutputFileName(), func(output io.Writer) error {
+			return g.generate(output, file)
+		}, out, log)
+
+		log.Log(logger.PROMPT, "Done scan with %s\n\n", filepath.Join(pkgDir, fileInfo.Name()))
+	}
+}
+
+// OutputFileName returns path of the generated file, it is the mockc_ file in
+// Dir unless an output file or directory is specified
+func (options *Options) OutputFileName() string {
+	name, err := options.fileName()
+	if err != nil {
+		// invalid template is reported by Generate
+		name, _ = options.renderFileName(DefaultFileName)
+	}
+
+	if options.Output == "" {
+		return filepath.Join(options.Dir, name)
+	}
+
+	output := options.Output
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(options.Dir, output)
+	}
+
+	if strings.HasSuffix(options.Output, "/") || strings.HasSuffix(options.Output, string(filepath.Separator)) {
+		return filepath.Join(output, name)
+	}
+
+	if fi, err := os.Stat(output); err == nil && fi.IsDir() {
+		return filepath.Join(output, name)
+	}
+	return filepath.Clean(output)
+}
+
+// templateFileName returns path of Template, it is relative to Dir unless it is
+// an absolute path
+func (options *Options) templateFileName() string {
+	if options.Template == "" || filepath.IsAbs(options.Template) {
+		return options.Template
+	}
+	return filepath.Join(options.Dir, options.Template)
+}
+
+// DefaultFileName is the template of generated file names if FileName is not set,
+// the template is executed with .Name, .Package and .TestOnly of options
+const DefaultFileName = "mockc_{{.Name}}{{if .TestOnly}}_test{{end}}.go"
+
+// fileName returns name of the generated file rendered with FileName template,
+// or with DefaultFileName if it is not set
+func (options *Options) fileName() (string, error) {
+	if options.FileName == "" {
+		return options.renderFileName(DefaultFileName)
+	}
+	return options.renderFileName(options.FileName)
+}
+
+// renderFileName renders file name template fileName with options
+func (options *Options) renderFileName(fileName string) (string, error) {
+	tmpl, err := template.New("fileName").Option("missingkey=error").Parse(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	err = tmpl.Execute(&b, struct {
+		Name     string
+		Package  string
+		TestOnly bool
+	}{options.MockName, options.MockPkg, options.TestOnly})
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(b.String())
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("%q renders invalid file name %q", fileName, name)
+	}
+	return name, nil
+}
+
+// generateOutputFile formats generated content in memory and hands it over to out,
+// nothing is collected if generate fails or produces no content
+func generateOutputFile(
+	outputFileName string,
+	generate func(output io.Writer) error,
+	out *collector,
+	log *reporter,
+) {
+	var output bytes.Buffer
+	if err := generate(&output); err != nil {
+		log.Log(logger.ERROR, "Error in generating %s, error: %s\n", outputFileName, err)
+		return
+	}
+
+	if output.Len() == 0 {
+		return
+	}
+
+	content, err := gofile.FormatGoSource(outputFileName, output.Bytes())
+	if err != nil {
+		// keep unformatted content for troubleshooting
+		log.Log(logger.ERROR, "%s\n", err)
+		content = output.Bytes()
+	}
+
+	out.write(outputFileName, content)
+}
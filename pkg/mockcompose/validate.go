@@ -0,0 +1,350 @@
+package mockcompose
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kelveny/mockcompose/pkg/gogen"
+	"github.com/kelveny/mockcompose/pkg/gosyntax"
+	"github.com/kelveny/mockcompose/pkg/gotype"
+	"github.com/kelveny/mockcompose/pkg/logger"
+)
+
+// Closure is an entry of Options.MethodsToClone, in format of
+// method[,(this|.|<pkg>[=<mockClz>])[:(this|.|<pkg>[=<mockClz>])]*]
+type Closure struct {
+	Method    string
+	Peers     bool              // peer callee methods are mocked, i.e., this
+	Packages  []string          // packages of which callee functions are mocked, . for the package itself
+	Overrides map[string]string // package -> class that mocks callee functions of the package
+}
+
+// ParseClosure parses an entry of Options.MethodsToClone
+func ParseClosure(spec string) (*Closure, error) {
+	tokens := strings.Split(spec, ",")
+	if len(tokens) > 2 {
+		return nil, fmt.Errorf("%q has more than one closure", spec)
+	}
+
+	closure := &Closure{Method: strings.TrimSpace(tokens[0])}
+	if !token.IsIdentifier(closure.Method) {
+		return nil, fmt.Errorf("%q does not start with a method name", spec)
+	}
+
+	if len(tokens) == 1 {
+		return closure, nil
+	}
+
+	for _, pair := range strings.Split(tokens[1], ":") {
+		kv := strings.Split(pair, "=")
+		switch {
+		case len(kv) > 2:
+			return nil, fmt.Errorf("invalid package override usage %q in %q", pair, spec)
+		case len(kv) == 2:
+			if kv[0] == "." || kv[0] == "this" {
+				return nil, fmt.Errorf("invalid package override usage %q in %q, %s can not be overridden", pair, spec, kv[0])
+			}
+			if !token.IsIdentifier(kv[0]) || !token.IsIdentifier(kv[1]) {
+				return nil, fmt.Errorf("invalid package override usage %q in %q, package=class is expected", pair, spec)
+			}
+			if closure.Overrides == nil {
+				closure.Overrides = make(map[string]string)
+			}
+			closure.Overrides[kv[0]] = kv[1]
+		case kv[0] == "this":
+			closure.Peers = true
+		case kv[0] == "." || token.IsIdentifier(kv[0]):
+			closure.Packages = append(closure.Packages, kv[0])
+		default:
+			return nil, fmt.Errorf("invalid package %q in %q", pair, spec)
+		}
+	}
+	return closure, nil
+}
+
+// Validate checks options against source code in options.Dir without generating
+// code. Problems are returned as an *Error, diagnostics tell
+// the options they are about with Diagnostic.Option
+func Validate(ctx context.Context, options Options) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	options.useLoader()
+	v := &validator{options: &options}
+	v.checkOptions()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	v.checkSource()
+
+	if len(v.diagnostics) > 0 {
+		return &Error{Diagnostics: v.diagnostics}
+	}
+	return nil
+}
+
+type validator struct {
+	options     *Options
+	closures    []*Closure // parsed MethodsToClone, nil for malformed ones
+	diagnostics []Diagnostic
+}
+
+func (v *validator) fail(kind Kind, option string, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		Level:   logger.ERROR,
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+		Option:  option,
+	})
+}
+
+// checkOptions checks options that conflict with each other
+func (v *validator) checkOptions() {
+	options := v.options
+
+	if options.MockName == "" {
+		v.fail(UsageError, "name", "name of the generated class is not specified")
+	}
+
+	if _, err := gogen.NewBackend(options.Backend, gogen.BackendOptions{}); err != nil {
+		v.fail(UsageError, "backend", "%s", err)
+	}
+
+	if _, err := options.fileName(); err != nil {
+		v.fail(UsageError, "fileName", "invalid fileName template: %s", err)
+	}
+
+	if options.Template != "" {
+		if _, err := gogen.ParseTemplateFile(options.templateFileName()); err != nil {
+			v.fail(UsageError, "template", "failed to load template: %s", err)
+		}
+	}
+
+	if options.Spy {
+		if options.IntfName == "" {
+			v.fail(UsageError, "spy", "spy only applies to interface mocks")
+		} else if options.Backend == "gomock" {
+			v.fail(UsageError, "spy", "spy is not supported by gomock backend")
+		}
+	}
+
+	if options.Expecter && (options.Backend == "gomock" || options.Backend == "fake") {
+		v.fail(UsageError, "expecter", "expecter is not supported by %s backend", options.Backend)
+	}
+
+	if options.ClzName != "" && options.IntfName != "" {
+		v.fail(UsageError, "interfaceName", "className and interfaceName are exclusive")
+	}
+
+	switch {
+	case options.ClzName != "":
+		if len(options.MethodsToClone) == 0 {
+			v.fail(UsageError, "className", "at least one real method is required for class %s", options.ClzName)
+		}
+	case options.IntfName != "":
+	case len(options.MethodsToClone) > 0 && len(options.MethodsToMock) > 0:
+		v.fail(UsageError, "mock", "real and mock are exclusive in function clone generation")
+	case len(options.MethodsToClone) == 0 && len(options.MethodsToMock) == 0:
+		v.fail(UsageError, "", "no class, interface or function to generate against")
+	}
+
+	for i, spec := range options.MethodsToClone {
+		closure, err := ParseClosure(spec)
+		if err != nil {
+			v.fail(UsageError, fmt.Sprintf("real[%d]", i), "%s", err)
+		}
+		v.closures = append(v.closures, closure)
+	}
+}
+
+// checkSource checks that classes, interfaces, methods and packages referred by
+// options exist in source code
+func (v *validator) checkSource() {
+	options := v.options
+
+	switch {
+	case options.ClzName != "" || len(options.MethodsToClone) > 0:
+		files, ok := v.parseCWDPackage()
+		if !ok {
+			return
+		}
+		v.checkClass(files)
+	case options.IntfName != "":
+		v.checkInterface()
+	default:
+		v.checkFunctions()
+	}
+}
+
+// parseCWDPackage parses package in directory of options the way class
+// methods are generated
+func (v *validator) parseCWDPackage() ([]*ast.File, bool) {
+	pkgDir, err := filepath.Abs(v.options.Dir)
+	if err != nil {
+		v.fail(GenerationError, "", "Error in accessing file system. error: %s", err)
+		return nil, false
+	}
+
+	fileInfos, err := ioutil.ReadDir(pkgDir)
+	if err != nil {
+		v.fail(GenerationError, "", "Error in accessing file system. error: %s", err)
+		return nil, false
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, fileInfo := range fileInfos {
+		if !isPackageFile(pkgDir, fileInfo, &reporter{}) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(pkgDir, fileInfo.Name()), nil, 0)
+		if err != nil {
+			v.fail(SourceError, "", "Error in parsing %s, error: %s", filepath.Join(pkgDir, fileInfo.Name()), err)
+			return nil, false
+		}
+		files = append(files, file)
+	}
+
+	return filterPackageFiles(files, v.options.ClzName, &reporter{}), true
+}
+
+func (v *validator) checkClass(files []*ast.File) {
+	clzName := v.options.ClzName
+
+	if clzName != "" {
+		declared := false
+		for _, file := range files {
+			if gosyntax.FindTypeSpec(file, clzName) != nil {
+				declared = true
+				break
+			}
+		}
+
+		if !declared {
+			v.fail(GenerationError, "className", "class %s is not declared in current working directory", clzName)
+			return
+		}
+	}
+
+	for i, closure := range v.closures {
+		if closure == nil {
+			continue
+		}
+
+		option := fmt.Sprintf("real[%d]", i)
+		file := findMethodFile(files, clzName, closure.Method)
+		if file == nil {
+			v.fail(GenerationError, option, "%s is not declared in current working directory", describeMethod(clzName, closure.Method))
+			continue
+		}
+
+		imports := gosyntax.GetFileImportsAsMap(file)
+		pkgs := append([]string{}, closure.Packages...)
+		for pkg := range closure.Overrides {
+			pkgs = append(pkgs, pkg)
+		}
+		sort.Strings(pkgs[len(closure.Packages):])
+
+		for _, pkg := range pkgs {
+			if _, ok := imports[pkg]; !ok {
+				v.fail(GenerationError, option, "package %s is not imported by %s", pkg, describeMethod(clzName, closure.Method))
+			}
+		}
+	}
+
+	for i, name := range v.options.MethodsToMock {
+		if findMethodFile(files, clzName, name) == nil {
+			v.fail(GenerationError, fmt.Sprintf("mock[%d]", i), "%s is not declared in current working directory", describeMethod(clzName, name))
+		}
+	}
+}
+
+func (v *validator) checkInterface() {
+	options := v.options
+
+	if options.SrcPkg != "" {
+		pkg, err := options.Loader.LoadPackage(options.Dir, options.SrcPkg)
+		if err != nil {
+			v.fail(SourceError, "sourcePkg", "Error in loading package %s, error: %s", options.SrcPkg, err)
+			return
+		}
+
+		if gotype.FindInterface(pkg, options.IntfName) == nil {
+			v.fail(GenerationError, "interfaceName", "interface %s is not declared in package %s", options.IntfName, options.SrcPkg)
+		}
+		return
+	}
+
+	files, ok := v.parseCWDPackage()
+	if !ok {
+		return
+	}
+
+	for _, file := range files {
+		if tspec := gosyntax.FindTypeSpec(file, options.IntfName); tspec != nil {
+			if _, ok := tspec.Type.(*ast.InterfaceType); ok {
+				return
+			}
+		}
+	}
+	v.fail(GenerationError, "interfaceName", "interface %s is not declared in current working directory", options.IntfName)
+}
+
+func (v *validator) checkFunctions() {
+	options := v.options
+
+	pattern := options.SrcPkg
+	if pattern == "" {
+		pattern = "."
+	}
+
+	pkg, err := options.Loader.LoadPackage(options.Dir, pattern)
+	if err != nil {
+		v.fail(SourceError, "sourcePkg", "Error in loading package %s, error: %s", pattern, err)
+		return
+	}
+
+	if pkg.Types == nil {
+		for _, err := range pkg.Errors {
+			v.fail(SourceError, "sourcePkg", "%s error: %s", pkg.ID, err.Msg)
+		}
+		return
+	}
+
+	for i, name := range options.MethodsToMock {
+		if gotype.FindFuncSignature(pkg, name) == nil {
+			v.fail(GenerationError, fmt.Sprintf("mock[%d]", i), "function %s is not declared in package %s", name, pkg.PkgPath)
+		}
+	}
+}
+
+// findMethodFile returns file in which method of class is declared, or function
+// if clzName is empty
+func findMethodFile(files []*ast.File, clzName string, method string) *ast.File {
+	for _, file := range files {
+		for _, d := range file.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Name.Name == method {
+				if getReceiverTypeName(fn) == clzName {
+					return file
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func describeMethod(clzName string, method string) string {
+	if clzName == "" {
+		return "function " + method
+	}
+	return fmt.Sprintf("method %s of class %s", method, clzName)
+}
@@ -0,0 +1,100 @@
+package mockcompose
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kelveny/mockcompose/pkg/logger"
+)
+
+func TestParseClosure(t *testing.T) {
+	assert := require.New(t)
+
+	closure, err := ParseClosure("Foo,this:.:fmt:json=jsonMock")
+	assert.NoError(err)
+	assert.Equal(&Closure{
+		Method:    "Foo",
+		Peers:     true,
+		Packages:  []string{".", "fmt"},
+		Overrides: map[string]string{"json": "jsonMock"},
+	}, closure)
+
+	closure, err = ParseClosure("Foo")
+	assert.NoError(err)
+	assert.Equal(&Closure{Method: "Foo"}, closure)
+
+	for _, spec := range []string{"Foo,fmt=", "Foo,", "Foo,fmt::json", ",fmt", "Foo,.=dotMock", "Foo,fmt,json", "Foo,a=b=c"} {
+		_, err := ParseClosure(spec)
+		assert.Error(err, spec)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert := require.New(t)
+
+	cwd, err := os.Getwd()
+	assert.NoError(err)
+	assert.NoError(os.Chdir("../../test/yaml"))
+	defer os.Chdir(cwd)
+
+	assert.NoError(Validate(context.Background(), Options{
+		MockName:       "mockSampleClz",
+		ClzName:        "sampleClz",
+		MethodsToClone: []string{"methodThatUsesMultileGlobalFunctions,fmt:json"},
+	}))
+	assert.NoError(Validate(context.Background(), Options{
+		MockName: "mockFmt",
+		SrcPkg:   "fmt",
+		IntfName: "Stringer",
+	}))
+
+	err = Validate(context.Background(), Options{
+		MockName: "mockSampleClz",
+		ClzName:  "sampleClz",
+		IntfName: "SampleInterface",
+		Backend:  "mockery",
+		MethodsToClone: []string{
+			"methodThatUsesGlobalFunction,fmt=",
+			"methodThatUsesGlobalFunction,os",
+			"nope,fmt",
+		},
+	})
+	var genErr *Error
+	assert.ErrorAs(err, &genErr)
+	assert.Equal(UsageError, genErr.Kind())
+
+	options := []string{}
+	for _, d := range genErr.Diagnostics {
+		assert.Equal(logger.ERROR, d.Level)
+		options = append(options, d.Option)
+	}
+	assert.Equal([]string{"backend", "interfaceName", "real[0]", "real[1]", "real[2]"}, options)
+	assert.Equal("package os is not imported by method methodThatUsesGlobalFunction of class sampleClz", genErr.Diagnostics[3].Message)
+	assert.Equal("method nope of class sampleClz is not declared in current working directory", genErr.Diagnostics[4].Message)
+
+	err = Validate(context.Background(), Options{
+		MockName:      "mockFmt",
+		SrcPkg:        "fmt",
+		MethodsToMock: []string{"Sprintf", "Nope"},
+	})
+	assert.ErrorAs(err, &genErr)
+	assert.Equal(GenerationError, genErr.Kind())
+	assert.Len(genErr.Diagnostics, 1)
+	assert.Equal("mock[1]", genErr.Diagnostics[0].Option)
+
+	err = Validate(context.Background(), Options{
+		MockName: "mockFmt",
+		SrcPkg:   "fmt",
+		IntfName: "Stringer",
+		Expecter: true,
+		Backend:  "fake",
+	})
+	assert.ErrorAs(err, &genErr)
+	assert.Equal(UsageError, genErr.Kind())
+	assert.Len(genErr.Diagnostics, 1)
+	assert.Equal("expecter", genErr.Diagnostics[0].Option)
+	assert.Equal("expecter is not supported by fake backend", genErr.Diagnostics[0].Message)
+}
@@ -18,6 +18,22 @@ type mock_fooBarMock_BarFoo_bar struct {
 	mock.Mock
 }
 
+func newFooBarMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src fooBar) *fooBarMock {
+	m := &fooBarMock{fooBar: src}
+	m.Mock.Test(t)
+	m.mock_fooBarMock_BarFoo_bar.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_fooBarMock_BarFoo_bar.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (f *fooBarMock) FooBar() string {
 	if f.order()%2 == 0 {
 		fmt.Printf("ordinal order\n")
@@ -32,6 +32,28 @@ type mock_clonedFuncs_functionThatUsesMultileGlobalFunctions2_fmt struct {
 	mock.Mock
 }
 
+func newClonedFuncs(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *clonedFuncs {
+	m := &clonedFuncs{}
+	m.Mock.Test(t)
+	m.mock_clonedFuncs_functionThatUsesGlobalFunction_fmt.Test(t)
+	m.mock_clonedFuncs_functionThatUsesMultileGlobalFunctions_fmt.Test(t)
+	m.mock_clonedFuncs_functionThatUsesMultileGlobalFunctions_json.Test(t)
+	m.mock_clonedFuncs_functionThatUsesMultileGlobalFunctions2_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_clonedFuncs_functionThatUsesGlobalFunction_fmt.AssertExpectations(t)
+		m.mock_clonedFuncs_functionThatUsesMultileGlobalFunctions_fmt.AssertExpectations(t)
+		m.mock_clonedFuncs_functionThatUsesMultileGlobalFunctions_json.AssertExpectations(t)
+		m.mock_clonedFuncs_functionThatUsesMultileGlobalFunctions2_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *clonedFuncs) functionThatUsesGlobalFunction(format string, args ...interface{}) string {
 	fmt := &m.mock_clonedFuncs_functionThatUsesGlobalFunction_fmt
 
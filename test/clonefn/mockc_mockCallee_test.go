@@ -17,6 +17,22 @@ type mock_mockCallee_functionThatUsesFunctionFromSameRoot_foo struct {
 	mock.Mock
 }
 
+func newMockCallee(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockCallee {
+	m := &mockCallee{}
+	m.Mock.Test(t)
+	m.mock_mockCallee_functionThatUsesFunctionFromSameRoot_foo.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockCallee_functionThatUsesFunctionFromSameRoot_foo.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockCallee) functionThatUsesFunctionFromSameRoot() string {
 	foo := &m.mock_mockCallee_functionThatUsesFunctionFromSameRoot_foo
 
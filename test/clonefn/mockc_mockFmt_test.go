@@ -10,6 +10,20 @@ type mockFmt struct {
 	mock.Mock
 }
 
+func newMockFmt(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockFmt {
+	m := &mockFmt{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockFmt) Sprintf(format string, a ...interface{}) string {
 
 	_mc_args := make([]interface{}, 0, 1+len(a))
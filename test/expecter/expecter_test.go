@@ -0,0 +1,99 @@
+package expecter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceExpecter(t *testing.T) {
+	assert := require.New(t)
+
+	var key string
+	s := &mockStore{}
+	s.EXPECT().Get("name").Run(func(k string) { key = k }).Return("value", nil)
+	s.EXPECT().Get("missing").Return("", errors.New("not found"))
+	s.EXPECT().Put("name", mock.Anything).Return()
+	s.EXPECT().Len().Return(1)
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value", v)
+	assert.Equal("name", key)
+
+	_, err = s.Get("missing")
+	assert.EqualError(err, "not found")
+
+	s.Put("name", "value")
+	assert.Equal(1, s.Len())
+
+	s.AssertExpectations(t)
+}
+
+func TestInterfaceExpecterVariadic(t *testing.T) {
+	assert := require.New(t)
+
+	var tags []string
+	s := &mockStore{}
+	s.EXPECT().Keys("a", "t1", "t2").
+		Run(func(prefix string, t ...string) { tags = t }).
+		Return([]string{"a1"})
+
+	assert.Equal([]string{"a1"}, s.Keys("a", "t1", "t2"))
+	assert.Equal([]string{"t1", "t2"}, tags)
+}
+
+func TestInterfaceExpecterRunAndReturn(t *testing.T) {
+	assert := require.New(t)
+
+	s := &mockStore{}
+	s.EXPECT().Get(mock.Anything).RunAndReturn(func(key string) (string, error) {
+		return "value of " + key, nil
+	})
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value of name", v)
+}
+
+func TestGenericInterfaceExpecter(t *testing.T) {
+	assert := require.New(t)
+
+	p := &mockPair[string, int]{}
+	p.EXPECT().Set("k", 1).Return(true)
+	p.EXPECT().Set("k", 2).RunAndReturn(func(k string, v int) bool { return v > 2 })
+
+	assert.True(p.Set("k", 1))
+	assert.False(p.Set("k", 2))
+}
+
+func TestFunctionExpecter(t *testing.T) {
+	assert := require.New(t)
+
+	m := &mockStrconv{}
+	m.EXPECT().Atoi("x").Return(0, errors.New("invalid"))
+	m.EXPECT().Itoa(1).Return("one")
+
+	_, err := m.Atoi("x")
+	assert.EqualError(err, "invalid")
+	assert.Equal("one", m.Itoa(1))
+}
+
+func TestClassAndPackageExpecter(t *testing.T) {
+	assert := require.New(t)
+
+	g := &mockGreeter{Greeter: Greeter{greeting: "hello"}}
+	g.EXPECT().known("bob").Return(true)
+	g.mock_mockGreeter_Greet_fmt.EXPECT().
+		Sprintf(mock.Anything, "hello", "bob").
+		RunAndReturn(func(format string, a ...interface{}) string {
+			return fmt.Sprintf("mocked: "+format, a...)
+		})
+
+	assert.Equal("mocked: hello again, bob", g.Greet("bob"))
+
+	g.AssertExpectations(t)
+}
@@ -0,0 +1,165 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package expecter
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockGreeter struct {
+	Greeter
+	mock.Mock
+	mock_mockGreeter_Greet_fmt
+}
+
+type mock_mockGreeter_Greet_fmt struct {
+	mock.Mock
+}
+
+func newMockGreeter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src Greeter) *mockGreeter {
+	m := &mockGreeter{Greeter: src}
+	m.Mock.Test(t)
+	m.mock_mockGreeter_Greet_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockGreeter_Greet_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
+type mockGreeter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (m *mockGreeter) EXPECT() *mockGreeter_Expecter {
+	return &mockGreeter_Expecter{mock: &m.Mock}
+}
+
+type mock_mockGreeter_Greet_fmt_Expecter struct {
+	mock *mock.Mock
+}
+
+func (m *mock_mockGreeter_Greet_fmt) EXPECT() *mock_mockGreeter_Greet_fmt_Expecter {
+	return &mock_mockGreeter_Greet_fmt_Expecter{mock: &m.Mock}
+}
+func (g *mockGreeter) Greet(name string) string {
+	fmt := &g.mock_mockGreeter_Greet_fmt
+
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, name)
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+func (m *mockGreeter) known(name string) bool {
+
+	_mc_ret := m.Called(name)
+
+	var _r0 bool
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) bool); ok {
+		_r0 = _rfn(name)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(bool)
+		}
+	}
+
+	return _r0
+
+}
+
+type mockGreeter_known_Call struct {
+	*mock.Call
+}
+
+func (_e *mockGreeter_Expecter) known(name interface{}) *mockGreeter_known_Call {
+	return &mockGreeter_known_Call{Call: _e.mock.On("known", name)}
+}
+
+func (_c *mockGreeter_known_Call) Run(run func(name string)) *mockGreeter_known_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		run(_a0)
+	})
+	return _c
+}
+
+func (_c *mockGreeter_known_Call) Return(_r0 bool) *mockGreeter_known_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *mockGreeter_known_Call) RunAndReturn(run func(string) bool) *mockGreeter_known_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (m *mock_mockGreeter_Greet_fmt) Sprintf(format string, a ...interface{}) string {
+
+	_mc_args := make([]interface{}, 0, 1+len(a))
+
+	_mc_args = append(_mc_args, format)
+
+	for _, _va := range a {
+		_mc_args = append(_mc_args, _va)
+	}
+
+	_mc_ret := m.Called(_mc_args...)
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, ...interface{}) string); ok {
+		_r0 = _rfn(format, a...)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	return _r0
+
+}
+
+type mock_mockGreeter_Greet_fmt_Sprintf_Call struct {
+	*mock.Call
+}
+
+func (_e *mock_mockGreeter_Greet_fmt_Expecter) Sprintf(format interface{}, a ...interface{}) *mock_mockGreeter_Greet_fmt_Sprintf_Call {
+	return &mock_mockGreeter_Greet_fmt_Sprintf_Call{Call: _e.mock.On("Sprintf", append([]interface{}{format}, a...)...)}
+}
+
+func (_c *mock_mockGreeter_Greet_fmt_Sprintf_Call) Run(run func(format string, a ...interface{})) *mock_mockGreeter_Greet_fmt_Sprintf_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		_a1 := make([]interface{}, len(_args)-1)
+		for _i, _a := range _args[1:] {
+			if _a != nil {
+				_a1[_i] = _a.(interface{})
+			}
+		}
+		run(_a0, _a1...)
+	})
+	return _c
+}
+
+func (_c *mock_mockGreeter_Greet_fmt_Sprintf_Call) Return(_r0 string) *mock_mockGreeter_Greet_fmt_Sprintf_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *mock_mockGreeter_Greet_fmt_Sprintf_Call) RunAndReturn(run func(string, ...interface{}) string) *mock_mockGreeter_Greet_fmt_Sprintf_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,83 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package expecter
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPair[K comparable, V any] struct {
+	mock.Mock
+}
+
+func newMockPair[K comparable, V any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockPair[K, V] {
+	m := &mockPair[K, V]{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+type mockPair_Expecter[K comparable, V any] struct {
+	mock *mock.Mock
+}
+
+func (m *mockPair[K, V]) EXPECT() *mockPair_Expecter[K, V] {
+	return &mockPair_Expecter[K, V]{mock: &m.Mock}
+}
+func (m *mockPair[K, V]) Set(k K, v V) bool {
+
+	_mc_ret := m.Called(k, v)
+
+	var _r0 bool
+
+	if _rfn, ok := _mc_ret.Get(0).(func(K, V) bool); ok {
+		_r0 = _rfn(k, v)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(bool)
+		}
+	}
+
+	return _r0
+
+}
+
+type mockPair_Set_Call[K comparable, V any] struct {
+	*mock.Call
+}
+
+func (_e *mockPair_Expecter[K, V]) Set(k interface{}, v interface{}) *mockPair_Set_Call[K, V] {
+	return &mockPair_Set_Call[K, V]{Call: _e.mock.On("Set", k, v)}
+}
+
+func (_c *mockPair_Set_Call[K, V]) Run(run func(k K, v V)) *mockPair_Set_Call[K, V] {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 K
+		if _args[0] != nil {
+			_a0 = _args[0].(K)
+		}
+		var _a1 V
+		if _args[1] != nil {
+			_a1 = _args[1].(V)
+		}
+		run(_a0, _a1)
+	})
+	return _c
+}
+
+func (_c *mockPair_Set_Call[K, V]) Return(_r0 bool) *mockPair_Set_Call[K, V] {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *mockPair_Set_Call[K, V]) RunAndReturn(run func(K, V) bool) *mockPair_Set_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,233 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package expecter
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStore struct {
+	mock.Mock
+}
+
+func newMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockStore {
+	m := &mockStore{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+type mockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (m *mockStore) EXPECT() *mockStore_Expecter {
+	return &mockStore_Expecter{mock: &m.Mock}
+}
+func (m *mockStore) Get(key string) (string, error) {
+
+	_mc_ret := m.Called(key)
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) (string, error)); ok {
+		return _rfn(key)
+	}
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) string); ok {
+		_r0 = _rfn(key)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(key)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+type mockStore_Get_Call struct {
+	*mock.Call
+}
+
+func (_e *mockStore_Expecter) Get(key interface{}) *mockStore_Get_Call {
+	return &mockStore_Get_Call{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *mockStore_Get_Call) Run(run func(key string)) *mockStore_Get_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		run(_a0)
+	})
+	return _c
+}
+
+func (_c *mockStore_Get_Call) Return(_r0 string, _r1 error) *mockStore_Get_Call {
+	_c.Call.Return(_r0, _r1)
+	return _c
+}
+
+func (_c *mockStore_Get_Call) RunAndReturn(run func(string) (string, error)) *mockStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (m *mockStore) Put(key string, value string) {
+
+	m.Called(key, value)
+
+}
+
+type mockStore_Put_Call struct {
+	*mock.Call
+}
+
+func (_e *mockStore_Expecter) Put(key interface{}, value interface{}) *mockStore_Put_Call {
+	return &mockStore_Put_Call{Call: _e.mock.On("Put", key, value)}
+}
+
+func (_c *mockStore_Put_Call) Run(run func(key string, value string)) *mockStore_Put_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		var _a1 string
+		if _args[1] != nil {
+			_a1 = _args[1].(string)
+		}
+		run(_a0, _a1)
+	})
+	return _c
+}
+
+func (_c *mockStore_Put_Call) Return() *mockStore_Put_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockStore_Put_Call) RunAndReturn(run func(string, string)) *mockStore_Put_Call {
+	return _c.Run(run)
+}
+
+func (m *mockStore) Keys(prefix string, tags ...string) []string {
+
+	_mc_args := make([]interface{}, 0, 1+len(tags))
+
+	_mc_args = append(_mc_args, prefix)
+
+	for _, _va := range tags {
+		_mc_args = append(_mc_args, _va)
+	}
+
+	_mc_ret := m.Called(_mc_args...)
+
+	var _r0 []string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, ...string) []string); ok {
+		_r0 = _rfn(prefix, tags...)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]string)
+		}
+	}
+
+	return _r0
+
+}
+
+type mockStore_Keys_Call struct {
+	*mock.Call
+}
+
+func (_e *mockStore_Expecter) Keys(prefix interface{}, tags ...interface{}) *mockStore_Keys_Call {
+	return &mockStore_Keys_Call{Call: _e.mock.On("Keys", append([]interface{}{prefix}, tags...)...)}
+}
+
+func (_c *mockStore_Keys_Call) Run(run func(prefix string, tags ...string)) *mockStore_Keys_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		_a1 := make([]string, len(_args)-1)
+		for _i, _a := range _args[1:] {
+			if _a != nil {
+				_a1[_i] = _a.(string)
+			}
+		}
+		run(_a0, _a1...)
+	})
+	return _c
+}
+
+func (_c *mockStore_Keys_Call) Return(_r0 []string) *mockStore_Keys_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *mockStore_Keys_Call) RunAndReturn(run func(string, ...string) []string) *mockStore_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (m *mockStore) Len() int {
+
+	_mc_ret := m.Called()
+
+	var _r0 int
+
+	if _rfn, ok := _mc_ret.Get(0).(func() int); ok {
+		_r0 = _rfn()
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(int)
+		}
+	}
+
+	return _r0
+
+}
+
+type mockStore_Len_Call struct {
+	*mock.Call
+}
+
+func (_e *mockStore_Expecter) Len() *mockStore_Len_Call {
+	return &mockStore_Len_Call{Call: _e.mock.On("Len")}
+}
+
+func (_c *mockStore_Len_Call) Run(run func()) *mockStore_Len_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockStore_Len_Call) Return(_r0 int) *mockStore_Len_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *mockStore_Len_Call) RunAndReturn(run func() int) *mockStore_Len_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,138 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package expecter
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStrconv struct {
+	mock.Mock
+}
+
+func newMockStrconv(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockStrconv {
+	m := &mockStrconv{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+type mockStrconv_Expecter struct {
+	mock *mock.Mock
+}
+
+func (m *mockStrconv) EXPECT() *mockStrconv_Expecter {
+	return &mockStrconv_Expecter{mock: &m.Mock}
+}
+func (m *mockStrconv) Atoi(s string) (int, error) {
+
+	_mc_ret := m.Called(s)
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) (int, error)); ok {
+		return _rfn(s)
+	}
+
+	var _r0 int
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) int); ok {
+		_r0 = _rfn(s)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(int)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(s)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+type mockStrconv_Atoi_Call struct {
+	*mock.Call
+}
+
+func (_e *mockStrconv_Expecter) Atoi(s interface{}) *mockStrconv_Atoi_Call {
+	return &mockStrconv_Atoi_Call{Call: _e.mock.On("Atoi", s)}
+}
+
+func (_c *mockStrconv_Atoi_Call) Run(run func(s string)) *mockStrconv_Atoi_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		run(_a0)
+	})
+	return _c
+}
+
+func (_c *mockStrconv_Atoi_Call) Return(_r0 int, _r1 error) *mockStrconv_Atoi_Call {
+	_c.Call.Return(_r0, _r1)
+	return _c
+}
+
+func (_c *mockStrconv_Atoi_Call) RunAndReturn(run func(string) (int, error)) *mockStrconv_Atoi_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (m *mockStrconv) Itoa(i int) string {
+
+	_mc_ret := m.Called(i)
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(int) string); ok {
+		_r0 = _rfn(i)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	return _r0
+
+}
+
+type mockStrconv_Itoa_Call struct {
+	*mock.Call
+}
+
+func (_e *mockStrconv_Expecter) Itoa(i interface{}) *mockStrconv_Itoa_Call {
+	return &mockStrconv_Itoa_Call{Call: _e.mock.On("Itoa", i)}
+}
+
+func (_c *mockStrconv_Itoa_Call) Run(run func(i int)) *mockStrconv_Itoa_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 int
+		if _args[0] != nil {
+			_a0 = _args[0].(int)
+		}
+		run(_a0)
+	})
+	return _c
+}
+
+func (_c *mockStrconv_Itoa_Call) Return(_r0 string) *mockStrconv_Itoa_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *mockStrconv_Itoa_Call) RunAndReturn(run func(int) string) *mockStrconv_Itoa_Call {
+	_c.Call.Return(run)
+	return _c
+}
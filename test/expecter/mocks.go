@@ -0,0 +1,5 @@
+//go:generate mockcompose -n mockStore -i Store -expecter
+//go:generate mockcompose -n mockPair -i Pair -expecter
+//go:generate mockcompose -n mockGreeter -c Greeter -real Greet,this:fmt -expecter
+//go:generate mockcompose -n mockStrconv -p strconv -mock Itoa -mock Atoi -expecter
+package expecter
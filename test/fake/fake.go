@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Store interface {
+	Get(key string) (string, error)
+	Put(key string, value string)
+	Delete(string) error
+	Keys(prefix string, tags ...string) []string
+}
+
+type Pair[K comparable, V any] interface {
+	Set(k K, v V) bool
+}
+
+type Greeter struct {
+	greeting string
+	calls    int
+}
+
+func (g *Greeter) Greet(name string) string {
+	g.calls++
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, strings.ToUpper(name))
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+func (g *Greeter) known(name string) bool {
+	return false
+}
+
+type Registry[K comparable, V any] struct {
+	items map[K]V
+}
+
+func (r *Registry[K, V]) Register(k K, v V) bool {
+	if r.exists(k) {
+		return false
+	}
+	r.add(k, v)
+	return true
+}
+
+func (r *Registry[K, V]) exists(k K) bool {
+	_, ok := r.items[k]
+	return ok
+}
+
+// peer methods may name type parameters differently
+func (r *Registry[A, B]) add(k A, v B) {
+	if r.items == nil {
+		r.items = make(map[A]B)
+	}
+	r.items[k] = v
+}
@@ -0,0 +1,99 @@
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeInterface(t *testing.T) {
+	assert := require.New(t)
+
+	s := &fakeStore{
+		GetFunc: func(key string) (string, error) {
+			if key == "name" {
+				return "value", nil
+			}
+			return "", errors.New("not found")
+		},
+		PutFunc: func(key string, value string) {},
+		KeysFunc: func(prefix string, tags ...string) []string {
+			return append([]string{prefix}, tags...)
+		},
+	}
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value", v)
+
+	_, err = s.Get("missing")
+	assert.EqualError(err, "not found")
+
+	s.Put("name", "value")
+	assert.Equal([]string{"a", "t1", "t2"}, s.Keys("a", "t1", "t2"))
+
+	assert.Equal([]fakeStoreGetCall{{Key: "name"}, {Key: "missing"}}, s.GetCalls())
+	assert.Equal([]fakeStorePutCall{{Key: "name", Value: "value"}}, s.PutCalls())
+	assert.Equal([]fakeStoreKeysCall{{Prefix: "a", Tags: []string{"t1", "t2"}}}, s.KeysCalls())
+
+	assert.Panics(func() { s.Delete("name") })
+}
+
+func TestFakeGenericInterface(t *testing.T) {
+	assert := require.New(t)
+
+	p := &fakePair[string, int]{
+		SetFunc: func(k string, v int) bool { return true },
+	}
+
+	assert.True(p.Set("k", 1))
+	assert.Equal([]fakePairSetCall[string, int]{{K: "k", V: 1}}, p.SetCalls())
+}
+
+func TestFakeFunctions(t *testing.T) {
+	assert := require.New(t)
+
+	m := &fakeStrconv{
+		AtoiFunc: func(s string) (int, error) { return 0, errors.New("invalid") },
+		ItoaFunc: func(i int) string { return "one" },
+	}
+
+	_, err := m.Atoi("x")
+	assert.EqualError(err, "invalid")
+	assert.Equal("one", m.Itoa(1))
+	assert.Equal([]fakeStrconvAtoiCall{{S: "x"}}, m.AtoiCalls())
+}
+
+func TestFakeClassComposite(t *testing.T) {
+	assert := require.New(t)
+
+	g := newFakeGreeter(Greeter{greeting: "hello"})
+	g.knownFunc = func(name string) bool { return name == "bob" }
+	g.SprintfFunc = func(format string, a ...interface{}) string {
+		return "mocked " + fmt.Sprint(a...)
+	}
+
+	assert.Equal("mocked helloBOB", g.Greet("bob"))
+	assert.Equal("mocked helloalice", g.Greet("alice"))
+
+	assert.Equal(2, g.calls)
+	assert.Equal([]fakeGreeterKnownCall{{Name: "bob"}, {Name: "alice"}}, g.knownCalls())
+	assert.Len(g.SprintfCalls(), 2)
+	assert.Equal("%s again, %s", g.SprintfCalls()[0].Format)
+}
+
+func TestFakeGenericClassComposite(t *testing.T) {
+	assert := require.New(t)
+
+	r := &fakeRegistry[string, int]{}
+	r.existsFunc = func(k string) bool { return k == "a" }
+	r.addFunc = func(k string, v int) {}
+
+	assert.False(r.Register("a", 1))
+	assert.True(r.Register("b", 2))
+
+	assert.Equal([]fakeRegistryExistsCall[string, int]{{K: "a"}, {K: "b"}}, r.existsCalls())
+	assert.Equal([]fakeRegistryAddCall[string, int]{{K: "b", V: 2}}, r.addCalls())
+}
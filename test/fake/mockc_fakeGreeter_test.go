@@ -0,0 +1,98 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package fake
+
+import (
+	"strings"
+	"sync"
+)
+
+type fakeGreeter struct {
+	Greeter
+	knownFunc func(name string) bool
+
+	mockcCalls struct {
+		known []fakeGreeterKnownCall
+	}
+	mockcLock sync.Mutex
+	mock_fakeGreeter_Greet_fmt
+}
+
+type mock_fakeGreeter_Greet_fmt struct {
+	SprintfFunc func(format string, a ...interface{}) string
+
+	mockcCalls struct {
+		Sprintf []mock_fakeGreeter_Greet_fmtSprintfCall
+	}
+	mockcLock sync.Mutex
+}
+
+func newFakeGreeter(src Greeter) *fakeGreeter {
+	return &fakeGreeter{Greeter: src}
+}
+
+func (g *fakeGreeter) Greet(name string) string {
+	fmt := &g.mock_fakeGreeter_Greet_fmt
+
+	g.calls++
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, strings.ToUpper(name))
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+type fakeGreeterKnownCall struct {
+	Name string
+}
+
+func (m *fakeGreeter) known(name string) bool {
+	if m.knownFunc == nil {
+		panic("fakeGreeter.knownFunc: method is nil but known was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.known = append(m.mockcCalls.known, fakeGreeterKnownCall{
+		Name: name,
+	})
+	m.mockcLock.Unlock()
+
+	return m.knownFunc(name)
+}
+
+func (m *fakeGreeter) knownCalls() []fakeGreeterKnownCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeGreeterKnownCall, len(m.mockcCalls.known))
+	copy(calls, m.mockcCalls.known)
+	return calls
+}
+
+type mock_fakeGreeter_Greet_fmtSprintfCall struct {
+	Format string
+	A      []interface{}
+}
+
+func (m *mock_fakeGreeter_Greet_fmt) Sprintf(format string, a ...interface{}) string {
+	if m.SprintfFunc == nil {
+		panic("mock_fakeGreeter_Greet_fmt.SprintfFunc: method is nil but Sprintf was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Sprintf = append(m.mockcCalls.Sprintf, mock_fakeGreeter_Greet_fmtSprintfCall{
+		Format: format,
+		A:      a,
+	})
+	m.mockcLock.Unlock()
+
+	return m.SprintfFunc(format, a...)
+}
+
+func (m *mock_fakeGreeter_Greet_fmt) SprintfCalls() []mock_fakeGreeter_Greet_fmtSprintfCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]mock_fakeGreeter_Greet_fmtSprintfCall, len(m.mockcCalls.Sprintf))
+	copy(calls, m.mockcCalls.Sprintf)
+	return calls
+}
@@ -0,0 +1,45 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package fake
+
+import (
+	"sync"
+)
+
+type fakePair[K comparable, V any] struct {
+	SetFunc func(k K, v V) bool
+
+	mockcCalls struct {
+		Set []fakePairSetCall[K, V]
+	}
+	mockcLock sync.Mutex
+}
+
+type fakePairSetCall[K comparable, V any] struct {
+	K K
+	V V
+}
+
+func (m *fakePair[K, V]) Set(k K, v V) bool {
+	if m.SetFunc == nil {
+		panic("fakePair.SetFunc: method is nil but Set was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Set = append(m.mockcCalls.Set, fakePairSetCall[K, V]{
+		K: k,
+		V: v,
+	})
+	m.mockcLock.Unlock()
+
+	return m.SetFunc(k, v)
+}
+
+func (m *fakePair[K, V]) SetCalls() []fakePairSetCall[K, V] {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakePairSetCall[K, V], len(m.mockcCalls.Set))
+	copy(calls, m.mockcCalls.Set)
+	return calls
+}
@@ -0,0 +1,87 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package fake
+
+import (
+	"sync"
+)
+
+type fakeRegistry[K comparable, V any] struct {
+	Registry[K, V]
+	existsFunc func(k K) bool
+	addFunc    func(k K, v V)
+
+	mockcCalls struct {
+		exists []fakeRegistryExistsCall[K, V]
+		add    []fakeRegistryAddCall[K, V]
+	}
+	mockcLock sync.Mutex
+}
+
+func newFakeRegistry[K comparable, V any](src Registry[K, V]) *fakeRegistry[K, V] {
+	return &fakeRegistry[K, V]{Registry: src}
+}
+
+func (r *fakeRegistry[K, V]) Register(k K, v V) bool {
+	if r.exists(k) {
+		return false
+	}
+	r.add(k, v)
+	return true
+}
+
+type fakeRegistryExistsCall[K comparable, V any] struct {
+	K K
+}
+
+func (m *fakeRegistry[K, V]) exists(k K) bool {
+	if m.existsFunc == nil {
+		panic("fakeRegistry.existsFunc: method is nil but exists was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.exists = append(m.mockcCalls.exists, fakeRegistryExistsCall[K, V]{
+		K: k,
+	})
+	m.mockcLock.Unlock()
+
+	return m.existsFunc(k)
+}
+
+func (m *fakeRegistry[K, V]) existsCalls() []fakeRegistryExistsCall[K, V] {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeRegistryExistsCall[K, V], len(m.mockcCalls.exists))
+	copy(calls, m.mockcCalls.exists)
+	return calls
+}
+
+type fakeRegistryAddCall[K comparable, V any] struct {
+	K K
+	V V
+}
+
+func (m *fakeRegistry[K, V]) add(k K, v V) {
+	if m.addFunc == nil {
+		panic("fakeRegistry.addFunc: method is nil but add was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.add = append(m.mockcCalls.add, fakeRegistryAddCall[K, V]{
+		K: k,
+		V: v,
+	})
+	m.mockcLock.Unlock()
+
+	m.addFunc(k, v)
+}
+
+func (m *fakeRegistry[K, V]) addCalls() []fakeRegistryAddCall[K, V] {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeRegistryAddCall[K, V], len(m.mockcCalls.add))
+	copy(calls, m.mockcCalls.add)
+	return calls
+}
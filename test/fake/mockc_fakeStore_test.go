@@ -0,0 +1,134 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package fake
+
+import (
+	"sync"
+)
+
+type fakeStore struct {
+	GetFunc    func(key string) (string, error)
+	PutFunc    func(key string, value string)
+	DeleteFunc func(_a0 string) error
+	KeysFunc   func(prefix string, tags ...string) []string
+
+	mockcCalls struct {
+		Get    []fakeStoreGetCall
+		Put    []fakeStorePutCall
+		Delete []fakeStoreDeleteCall
+		Keys   []fakeStoreKeysCall
+	}
+	mockcLock sync.Mutex
+}
+
+type fakeStoreGetCall struct {
+	Key string
+}
+
+func (m *fakeStore) Get(key string) (string, error) {
+	if m.GetFunc == nil {
+		panic("fakeStore.GetFunc: method is nil but Get was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Get = append(m.mockcCalls.Get, fakeStoreGetCall{
+		Key: key,
+	})
+	m.mockcLock.Unlock()
+
+	return m.GetFunc(key)
+}
+
+func (m *fakeStore) GetCalls() []fakeStoreGetCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeStoreGetCall, len(m.mockcCalls.Get))
+	copy(calls, m.mockcCalls.Get)
+	return calls
+}
+
+type fakeStorePutCall struct {
+	Key   string
+	Value string
+}
+
+func (m *fakeStore) Put(key string, value string) {
+	if m.PutFunc == nil {
+		panic("fakeStore.PutFunc: method is nil but Put was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Put = append(m.mockcCalls.Put, fakeStorePutCall{
+		Key:   key,
+		Value: value,
+	})
+	m.mockcLock.Unlock()
+
+	m.PutFunc(key, value)
+}
+
+func (m *fakeStore) PutCalls() []fakeStorePutCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeStorePutCall, len(m.mockcCalls.Put))
+	copy(calls, m.mockcCalls.Put)
+	return calls
+}
+
+type fakeStoreDeleteCall struct {
+	A0 string
+}
+
+func (m *fakeStore) Delete(_a0 string) error {
+	if m.DeleteFunc == nil {
+		panic("fakeStore.DeleteFunc: method is nil but Delete was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Delete = append(m.mockcCalls.Delete, fakeStoreDeleteCall{
+		A0: _a0,
+	})
+	m.mockcLock.Unlock()
+
+	return m.DeleteFunc(_a0)
+}
+
+func (m *fakeStore) DeleteCalls() []fakeStoreDeleteCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeStoreDeleteCall, len(m.mockcCalls.Delete))
+	copy(calls, m.mockcCalls.Delete)
+	return calls
+}
+
+type fakeStoreKeysCall struct {
+	Prefix string
+	Tags   []string
+}
+
+func (m *fakeStore) Keys(prefix string, tags ...string) []string {
+	if m.KeysFunc == nil {
+		panic("fakeStore.KeysFunc: method is nil but Keys was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Keys = append(m.mockcCalls.Keys, fakeStoreKeysCall{
+		Prefix: prefix,
+		Tags:   tags,
+	})
+	m.mockcLock.Unlock()
+
+	return m.KeysFunc(prefix, tags...)
+}
+
+func (m *fakeStore) KeysCalls() []fakeStoreKeysCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeStoreKeysCall, len(m.mockcCalls.Keys))
+	copy(calls, m.mockcCalls.Keys)
+	return calls
+}
@@ -0,0 +1,72 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package fake
+
+import (
+	"sync"
+)
+
+type fakeStrconv struct {
+	AtoiFunc func(s string) (int, error)
+	ItoaFunc func(i int) string
+
+	mockcCalls struct {
+		Atoi []fakeStrconvAtoiCall
+		Itoa []fakeStrconvItoaCall
+	}
+	mockcLock sync.Mutex
+}
+
+type fakeStrconvAtoiCall struct {
+	S string
+}
+
+func (m *fakeStrconv) Atoi(s string) (int, error) {
+	if m.AtoiFunc == nil {
+		panic("fakeStrconv.AtoiFunc: method is nil but Atoi was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Atoi = append(m.mockcCalls.Atoi, fakeStrconvAtoiCall{
+		S: s,
+	})
+	m.mockcLock.Unlock()
+
+	return m.AtoiFunc(s)
+}
+
+func (m *fakeStrconv) AtoiCalls() []fakeStrconvAtoiCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeStrconvAtoiCall, len(m.mockcCalls.Atoi))
+	copy(calls, m.mockcCalls.Atoi)
+	return calls
+}
+
+type fakeStrconvItoaCall struct {
+	I int
+}
+
+func (m *fakeStrconv) Itoa(i int) string {
+	if m.ItoaFunc == nil {
+		panic("fakeStrconv.ItoaFunc: method is nil but Itoa was just called")
+	}
+
+	m.mockcLock.Lock()
+	m.mockcCalls.Itoa = append(m.mockcCalls.Itoa, fakeStrconvItoaCall{
+		I: i,
+	})
+	m.mockcLock.Unlock()
+
+	return m.ItoaFunc(i)
+}
+
+func (m *fakeStrconv) ItoaCalls() []fakeStrconvItoaCall {
+	m.mockcLock.Lock()
+	defer m.mockcLock.Unlock()
+
+	calls := make([]fakeStrconvItoaCall, len(m.mockcCalls.Itoa))
+	copy(calls, m.mockcCalls.Itoa)
+	return calls
+}
@@ -0,0 +1,6 @@
+//go:generate mockcompose -n fakeStore -i Store -backend fake
+//go:generate mockcompose -n fakePair -i Pair -backend fake
+//go:generate mockcompose -n fakeGreeter -c Greeter -real Greet,this:fmt -backend fake
+//go:generate mockcompose -n fakeRegistry -c Registry -real Register,this -backend fake
+//go:generate mockcompose -n fakeStrconv -p strconv -mock Itoa -mock Atoi -backend fake
+package fake
@@ -10,6 +10,20 @@ type FooMock struct {
 	mock.Mock
 }
 
+func NewFooMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FooMock {
+	m := &FooMock{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *FooMock) Foo() string {
 
 	_mc_ret := m.Called()
@@ -21,6 +21,24 @@ type mock_testFoo_Foo_fmt struct {
 	mock.Mock
 }
 
+func newTestFoo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src foo) *testFoo {
+	m := &testFoo{foo: src}
+	m.Mock.Test(t)
+	m.mock_testFoo_Foo_foo.Test(t)
+	m.mock_testFoo_Foo_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_testFoo_Foo_foo.AssertExpectations(t)
+		m.mock_testFoo_Foo_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (f *testFoo) Foo() string {
 	dummy := f.mock_testFoo_Foo_foo.dummy
 	fmt := &f.mock_testFoo_Foo_fmt
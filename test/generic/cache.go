@@ -0,0 +1,40 @@
+package generic
+
+import "fmt"
+
+type Cache[K comparable, V any] struct {
+	items  map[K]V
+	loader func(K) (V, error)
+}
+
+func (c *Cache[K, V]) Get(k K) (V, error) {
+	if v, ok := c.lookup(k); ok {
+		return v, nil
+	}
+
+	v, err := c.load(k)
+	if err != nil {
+		var zero V
+		return zero, fmt.Errorf("failed to load %v: %w", k, err)
+	}
+
+	c.store(k, v)
+	return v, nil
+}
+
+func (c *Cache[K, V]) lookup(k K) (V, bool) {
+	v, ok := c.items[k]
+	return v, ok
+}
+
+func (c *Cache[K, V]) load(k K) (V, error) {
+	return c.loader(k)
+}
+
+// peer methods may name type parameters differently
+func (c *Cache[A, B]) store(k A, v B) {
+	if c.items == nil {
+		c.items = make(map[A]B)
+	}
+	c.items[k] = v
+}
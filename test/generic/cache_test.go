@@ -0,0 +1,102 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheGetFromLookup(t *testing.T) {
+	assert := require.New(t)
+
+	c := &cacheMock[string, int]{}
+	c.On("lookup", "key").Return(10, true)
+
+	v, err := c.Get("key")
+	assert.NoError(err)
+	assert.Equal(10, v)
+
+	c.AssertNotCalled(t, "load", "key")
+}
+
+func TestCacheGetFromLoader(t *testing.T) {
+	assert := require.New(t)
+
+	c := &cacheMock[string, int]{}
+	c.On("lookup", "key").Return(0, false)
+	c.On("load", "key").Return(20, nil)
+	c.On("store", "key", 20).Return()
+
+	v, err := c.Get("key")
+	assert.NoError(err)
+	assert.Equal(20, v)
+
+	c.AssertExpectations(t)
+}
+
+func TestCacheGetLoaderFailure(t *testing.T) {
+	assert := require.New(t)
+
+	c := &cacheMock[int, string]{}
+	c.On("lookup", 1).Return("", false)
+	c.On("load", 1).Return("", errors.New("unavailable"))
+
+	_, err := c.Get(1)
+	assert.EqualError(err, "failed to load 1: unavailable")
+
+	c.AssertNotCalled(t, "store", 1, "")
+}
+
+// fakeT records failures and cleanups of mocks bound by constructors
+type fakeT struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Logf(format string, args ...interface{}) {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) FailNow() {}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func TestCacheConstructor(t *testing.T) {
+	assert := require.New(t)
+
+	c := newCacheMock(t, Cache[string, int]{})
+	c.On("lookup", "key").Return(10, true)
+
+	v, err := c.Get("key")
+	assert.NoError(err)
+	assert.Equal(10, v)
+}
+
+func TestCacheConstructorAssertsAtCleanup(t *testing.T) {
+	assert := require.New(t)
+
+	ft := &fakeT{}
+	c := newCacheMock(ft, Cache[string, int]{})
+	c.On("lookup", "key").Return(10, true)
+
+	assert.Len(ft.cleanups, 1)
+	ft.cleanups[0]()
+	assert.NotEmpty(ft.errors)
+}
+
+func TestRepoConstructor(t *testing.T) {
+	assert := require.New(t)
+
+	r := NewRepoMock[string](t)
+	r.On("Get", "id").Return("value", nil)
+
+	v, err := r.Get("id")
+	assert.NoError(err)
+	assert.Equal("value", v)
+}
@@ -0,0 +1,45 @@
+package generic
+
+import "errors"
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+type Repo[T any] interface {
+	Get(id string) (T, error)
+	Put(id string, v T) error
+}
+
+type Aggregator[K comparable, V Number] interface {
+	Add(key K, v V)
+	Sum(keys ...K) V
+	Snapshot() map[K]V
+}
+
+type Ordered[T ~int | ~string] interface {
+	Less(a, b T) bool
+}
+
+type memRepo[T any] struct {
+	items map[string]T
+}
+
+var _ Repo[int] = (*memRepo[int])(nil)
+
+func (r *memRepo[T]) Get(id string) (T, error) {
+	if v, ok := r.items[id]; ok {
+		return v, nil
+	}
+
+	var zero T
+	return zero, errors.New("not found")
+}
+
+func (r *memRepo[T]) Put(id string, v T) error {
+	if r.items == nil {
+		r.items = make(map[string]T)
+	}
+	r.items[id] = v
+	return nil
+}
@@ -0,0 +1,67 @@
+package generic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericInterfaceMock(t *testing.T) {
+	assert := require.New(t)
+
+	m := &RepoMock[int]{}
+	m.On("Get", "found").Return(100, nil)
+	m.On("Get", "missing").Return(0, errors.New("not found"))
+	m.On("Put", "key", 1).Return(nil)
+
+	var r Repo[int] = m
+
+	v, err := r.Get("found")
+	assert.NoError(err)
+	assert.Equal(100, v)
+
+	_, err = r.Get("missing")
+	assert.Error(err)
+
+	assert.NoError(r.Put("key", 1))
+	m.AssertExpectations(t)
+}
+
+func TestGenericInterfaceMockWithReturnFunc(t *testing.T) {
+	assert := require.New(t)
+
+	m := &RepoMock[string]{}
+	m.On("Get", mock.Anything).Return(
+		func(id string) string {
+			return "value of " + id
+		},
+		nil,
+	)
+
+	v, err := m.Get("key")
+	assert.NoError(err)
+	assert.Equal("value of key", v)
+}
+
+func TestGenericInterfaceMockWithConstraints(t *testing.T) {
+	assert := require.New(t)
+
+	m := &aggregatorMock[string, float64]{}
+	m.On("Add", "a", 1.5).Return()
+	m.On("Sum", "a", "b").Return(3.0)
+	m.On("Snapshot").Return(map[string]float64{"a": 1.5})
+
+	var a Aggregator[string, float64] = m
+
+	a.Add("a", 1.5)
+	assert.Equal(3.0, a.Sum("a", "b"))
+	assert.Equal(map[string]float64{"a": 1.5}, a.Snapshot())
+
+	o := &orderedMock[string]{}
+	o.On("Less", "a", "b").Return(true)
+
+	var ordered Ordered[string] = o
+	assert.True(ordered.Less("a", "b"))
+}
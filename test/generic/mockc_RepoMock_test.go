@@ -0,0 +1,67 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package generic
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type RepoMock[T any] struct {
+	mock.Mock
+}
+
+func NewRepoMock[T any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RepoMock[T] {
+	m := &RepoMock[T]{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *RepoMock[T]) Get(id string) (T, error) {
+
+	_mc_ret := m.Called(id)
+
+	var _r0 T
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) T); ok {
+		_r0 = _rfn(id)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(T)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(id)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *RepoMock[T]) Put(id string, v T) error {
+
+	_mc_ret := m.Called(id, v)
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, T) error); ok {
+		_r0 = _rfn(id, v)
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
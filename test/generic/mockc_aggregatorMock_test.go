@@ -0,0 +1,73 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package generic
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type aggregatorMock[K comparable, V Number] struct {
+	mock.Mock
+}
+
+func newAggregatorMock[K comparable, V Number](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *aggregatorMock[K, V] {
+	m := &aggregatorMock[K, V]{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *aggregatorMock[K, V]) Add(key K, v V) {
+
+	m.Called(key, v)
+
+}
+
+func (m *aggregatorMock[K, V]) Sum(keys ...K) V {
+
+	_mc_args := make([]interface{}, 0, 0+len(keys))
+
+	for _, _va := range keys {
+		_mc_args = append(_mc_args, _va)
+	}
+
+	_mc_ret := m.Called(_mc_args...)
+
+	var _r0 V
+
+	if _rfn, ok := _mc_ret.Get(0).(func(...K) V); ok {
+		_r0 = _rfn(keys...)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(V)
+		}
+	}
+
+	return _r0
+
+}
+
+func (m *aggregatorMock[K, V]) Snapshot() map[K]V {
+
+	_mc_ret := m.Called()
+
+	var _r0 map[K]V
+
+	if _rfn, ok := _mc_ret.Get(0).(func() map[K]V); ok {
+		_r0 = _rfn()
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(map[K]V)
+		}
+	}
+
+	return _r0
+
+}
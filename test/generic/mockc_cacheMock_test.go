@@ -0,0 +1,101 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package generic
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type cacheMock[K comparable, V any] struct {
+	Cache[K, V]
+	mock.Mock
+}
+
+func newCacheMock[K comparable, V any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src Cache[K, V]) *cacheMock[K, V] {
+	m := &cacheMock[K, V]{Cache: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (c *cacheMock[K, V]) Get(k K) (V, error) {
+	if v, ok := c.lookup(k); ok {
+		return v, nil
+	}
+	v, err := c.load(k)
+	if err != nil {
+		var zero V
+		return zero, fmt.Errorf("failed to load %v: %w", k, err)
+	}
+	c.store(k, v)
+	return v, nil
+}
+
+func (m *cacheMock[K, V]) lookup(k K) (V, bool) {
+
+	_mc_ret := m.Called(k)
+
+	var _r0 V
+
+	if _rfn, ok := _mc_ret.Get(0).(func(K) V); ok {
+		_r0 = _rfn(k)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(V)
+		}
+	}
+
+	var _r1 bool
+
+	if _rfn, ok := _mc_ret.Get(1).(func(K) bool); ok {
+		_r1 = _rfn(k)
+	} else {
+		if _mc_ret.Get(1) != nil {
+			_r1 = _mc_ret.Get(1).(bool)
+		}
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *cacheMock[K, V]) load(k K) (V, error) {
+
+	_mc_ret := m.Called(k)
+
+	var _r0 V
+
+	if _rfn, ok := _mc_ret.Get(0).(func(K) V); ok {
+		_r0 = _rfn(k)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(V)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(K) error); ok {
+		_r1 = _rfn(k)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *cacheMock[A, B]) store(k A, v B) {
+
+	m.Called(k, v)
+
+}
@@ -0,0 +1,43 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package generic
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type orderedMock[T ~int | ~string] struct {
+	mock.Mock
+}
+
+func newOrderedMock[T ~int | ~string](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *orderedMock[T] {
+	m := &orderedMock[T]{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *orderedMock[T]) Less(a T, b T) bool {
+
+	_mc_ret := m.Called(a, b)
+
+	var _r0 bool
+
+	if _rfn, ok := _mc_ret.Get(0).(func(T, T) bool); ok {
+		_r0 = _rfn(a, b)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(bool)
+		}
+	}
+
+	return _r0
+
+}
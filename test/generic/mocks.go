@@ -0,0 +1,5 @@
+//go:generate mockcompose -n RepoMock -i Repo
+//go:generate mockcompose -n aggregatorMock -i Aggregator
+//go:generate mockcompose -n orderedMock -i Ordered -p github.com/kelveny/mockcompose/test/generic
+//go:generate mockcompose -n cacheMock -c Cache -real Get,this
+package generic
@@ -0,0 +1,31 @@
+package gomockgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Store interface {
+	Get(key string) (string, error)
+	Put(key string, value string)
+	Keys(prefix string, tags ...string) []string
+}
+
+type Pair[K comparable, V any] interface {
+	Set(k K, v V) bool
+}
+
+type Greeter struct {
+	greeting string
+}
+
+func (g *Greeter) Greet(name string) string {
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, strings.ToUpper(name))
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+func (g *Greeter) known(name string) bool {
+	return false
+}
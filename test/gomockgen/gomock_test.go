@@ -0,0 +1,69 @@
+package gomockgen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGomockInterface(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	s := NewMockStore(ctrl)
+
+	s.EXPECT().Get("name").Return("value", nil)
+	s.EXPECT().Get("missing").Return("", errors.New("not found"))
+	s.EXPECT().Put("name", gomock.Any())
+	s.EXPECT().Keys("a", "t1", "t2").DoAndReturn(func(prefix string, tags ...string) []string {
+		return append([]string{prefix}, tags...)
+	})
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value", v)
+
+	_, err = s.Get("missing")
+	assert.EqualError(err, "not found")
+
+	s.Put("name", "value")
+	assert.Equal([]string{"a", "t1", "t2"}, s.Keys("a", "t1", "t2"))
+}
+
+func TestGomockGenericInterface(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	p := newMockPair[string, int](ctrl)
+	p.EXPECT().Set("k", 1).Return(true)
+
+	assert.True(p.Set("k", 1))
+}
+
+func TestGomockFunctions(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	m := newMockStrconv(ctrl)
+	m.EXPECT().Atoi("x").Return(0, errors.New("invalid"))
+	m.EXPECT().Itoa(1).Return("one")
+
+	_, err := m.Atoi("x")
+	assert.EqualError(err, "invalid")
+	assert.Equal("one", m.Itoa(1))
+}
+
+func TestGomockClassComposite(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	g := newMockGreeter(ctrl, Greeter{greeting: "hello"})
+	g.EXPECT().known("bob").Return(true)
+	g.mock_mockGreeter_Greet_fmt.EXPECT().
+		Sprintf("%s again, %s", "hello", "BOB").
+		Return("mocked")
+
+	assert.Equal("mocked", g.Greet("bob"))
+}
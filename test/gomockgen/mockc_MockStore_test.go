@@ -0,0 +1,69 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package gomockgen
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	m := &MockStore{ctrl: ctrl}
+	m.recorder = &MockStoreMockRecorder{mock: m}
+
+	return m
+}
+
+func (m *MockStore) Get(key string) (string, error) {
+	m.ctrl.T.Helper()
+	_mc_ret := m.ctrl.Call(m, "Get", key)
+	_r0, _ := _mc_ret[0].(string)
+	_r1, _ := _mc_ret[1].(error)
+	return _r0, _r1
+}
+
+func (mr *MockStoreMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+}
+
+func (m *MockStore) Put(key string, value string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Put", key, value)
+}
+
+func (mr *MockStoreMockRecorder) Put(key interface{}, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), key, value)
+}
+
+func (m *MockStore) Keys(prefix string, tags ...string) []string {
+	m.ctrl.T.Helper()
+
+	_mc_args := []interface{}{prefix}
+	for _, _va := range tags {
+		_mc_args = append(_mc_args, _va)
+	}
+	_mc_ret := m.ctrl.Call(m, "Keys", _mc_args...)
+	_r0, _ := _mc_ret[0].([]string)
+	return _r0
+}
+
+func (mr *MockStoreMockRecorder) Keys(prefix interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockStore)(nil).Keys), append([]interface{}{prefix}, tags...)...)
+}
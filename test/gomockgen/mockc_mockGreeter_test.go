@@ -0,0 +1,85 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package gomockgen
+
+import (
+	"reflect"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+)
+
+type mockGreeter struct {
+	Greeter
+	ctrl     *gomock.Controller
+	recorder *mockGreeterMockRecorder
+	mock_mockGreeter_Greet_fmt
+}
+
+type mock_mockGreeter_Greet_fmt struct {
+	ctrl     *gomock.Controller
+	recorder *mock_mockGreeter_Greet_fmtMockRecorder
+}
+
+type mockGreeterMockRecorder struct {
+	mock *mockGreeter
+}
+
+func (m *mockGreeter) EXPECT() *mockGreeterMockRecorder {
+	return m.recorder
+}
+
+type mock_mockGreeter_Greet_fmtMockRecorder struct {
+	mock *mock_mockGreeter_Greet_fmt
+}
+
+func (m *mock_mockGreeter_Greet_fmt) EXPECT() *mock_mockGreeter_Greet_fmtMockRecorder {
+	return m.recorder
+}
+
+func newMockGreeter(ctrl *gomock.Controller, src Greeter) *mockGreeter {
+	m := &mockGreeter{ctrl: ctrl, Greeter: src}
+	m.recorder = &mockGreeterMockRecorder{mock: m}
+	m.mock_mockGreeter_Greet_fmt.ctrl = ctrl
+	m.mock_mockGreeter_Greet_fmt.recorder = &mock_mockGreeter_Greet_fmtMockRecorder{mock: &m.mock_mockGreeter_Greet_fmt}
+
+	return m
+}
+
+func (g *mockGreeter) Greet(name string) string {
+	fmt := &g.mock_mockGreeter_Greet_fmt
+
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, strings.ToUpper(name))
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+func (m *mockGreeter) known(name string) bool {
+	m.ctrl.T.Helper()
+	_mc_ret := m.ctrl.Call(m, "known", name)
+	_r0, _ := _mc_ret[0].(bool)
+	return _r0
+}
+
+func (mr *mockGreeterMockRecorder) known(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "known", reflect.TypeOf((*mockGreeter)(nil).known), name)
+}
+
+func (m *mock_mockGreeter_Greet_fmt) Sprintf(format string, a ...interface{}) string {
+	m.ctrl.T.Helper()
+
+	_mc_args := []interface{}{format}
+	for _, _va := range a {
+		_mc_args = append(_mc_args, _va)
+	}
+	_mc_ret := m.ctrl.Call(m, "Sprintf", _mc_args...)
+	_r0, _ := _mc_ret[0].(string)
+	return _r0
+}
+
+func (mr *mock_mockGreeter_Greet_fmtMockRecorder) Sprintf(format interface{}, a ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sprintf", reflect.TypeOf((*mock_mockGreeter_Greet_fmt)(nil).Sprintf), append([]interface{}{format}, a...)...)
+}
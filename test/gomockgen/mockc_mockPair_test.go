@@ -0,0 +1,41 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package gomockgen
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type mockPair[K comparable, V any] struct {
+	ctrl     *gomock.Controller
+	recorder *mockPairMockRecorder[K, V]
+}
+
+type mockPairMockRecorder[K comparable, V any] struct {
+	mock *mockPair[K, V]
+}
+
+func (m *mockPair[K, V]) EXPECT() *mockPairMockRecorder[K, V] {
+	return m.recorder
+}
+
+func newMockPair[K comparable, V any](ctrl *gomock.Controller) *mockPair[K, V] {
+	m := &mockPair[K, V]{ctrl: ctrl}
+	m.recorder = &mockPairMockRecorder[K, V]{mock: m}
+
+	return m
+}
+
+func (m *mockPair[K, V]) Set(k K, v V) bool {
+	m.ctrl.T.Helper()
+	_mc_ret := m.ctrl.Call(m, "Set", k, v)
+	_r0, _ := _mc_ret[0].(bool)
+	return _r0
+}
+
+func (mr *mockPairMockRecorder[K, V]) Set(k interface{}, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*mockPair[K, V])(nil).Set), k, v)
+}
@@ -0,0 +1,54 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package gomockgen
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type mockStrconv struct {
+	ctrl     *gomock.Controller
+	recorder *mockStrconvMockRecorder
+}
+
+type mockStrconvMockRecorder struct {
+	mock *mockStrconv
+}
+
+func (m *mockStrconv) EXPECT() *mockStrconvMockRecorder {
+	return m.recorder
+}
+
+func newMockStrconv(ctrl *gomock.Controller) *mockStrconv {
+	m := &mockStrconv{ctrl: ctrl}
+	m.recorder = &mockStrconvMockRecorder{mock: m}
+
+	return m
+}
+
+func (m *mockStrconv) Atoi(s string) (int, error) {
+	m.ctrl.T.Helper()
+	_mc_ret := m.ctrl.Call(m, "Atoi", s)
+	_r0, _ := _mc_ret[0].(int)
+	_r1, _ := _mc_ret[1].(error)
+	return _r0, _r1
+}
+
+func (mr *mockStrconvMockRecorder) Atoi(s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atoi", reflect.TypeOf((*mockStrconv)(nil).Atoi), s)
+}
+
+func (m *mockStrconv) Itoa(i int) string {
+	m.ctrl.T.Helper()
+	_mc_ret := m.ctrl.Call(m, "Itoa", i)
+	_r0, _ := _mc_ret[0].(string)
+	return _r0
+}
+
+func (mr *mockStrconvMockRecorder) Itoa(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Itoa", reflect.TypeOf((*mockStrconv)(nil).Itoa), i)
+}
@@ -0,0 +1,5 @@
+//go:generate mockcompose -n MockStore -i Store -backend gomock
+//go:generate mockcompose -n mockPair -i Pair -backend gomock
+//go:generate mockcompose -n mockGreeter -c Greeter -real Greet,this:fmt -backend gomock
+//go:generate mockcompose -n mockStrconv -p strconv -mock Itoa -mock Atoi -backend gomock
+package gomockgen
@@ -1,6 +1,9 @@
 package libfn
 
-import "regexp"
+import (
+	"io"
+	"regexp"
+)
 
 type SecretData struct {
 	Data []byte
@@ -14,3 +17,25 @@ func GetSecrets(projectId string, secretsRegexp *regexp.Regexp) ([]SecretData, e
 type SecretsInterface interface {
 	GetSecrets(projectId string, secretsRegexp *regexp.Regexp) ([]SecretData, error)
 }
+
+func Register(h interface{ Handle() error }) error {
+	return h.Handle()
+}
+
+func Open(name string) (interface {
+	io.Reader
+	io.Closer
+}, error) {
+	return nil, nil
+}
+
+type ResourceInterface interface {
+	Open(name string) (interface {
+		io.Reader
+		io.Closer
+	}, error)
+
+	Watch(filter interface {
+		Match(name string, tags ...string) bool
+	}) <-chan string
+}
@@ -11,6 +11,20 @@ type mix_checkAndSetOnTarget struct {
 	mock.Mock
 }
 
+func newMix_checkAndSetOnTarget(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src mixReceiver) *mix_checkAndSetOnTarget {
+	m := &mix_checkAndSetOnTarget{mixReceiver: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (v mix_checkAndSetOnTarget) checkAndSetOnTarget(p *mixReceiver, s string, val string) {
 	if v.getValue() != s {
 		v.setValue(val)
@@ -11,6 +11,20 @@ type mix_checkAndSet struct {
 	mock.Mock
 }
 
+func newMix_checkAndSet(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src mixReceiver) *mix_checkAndSet {
+	m := &mix_checkAndSet{mixReceiver: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (p *mix_checkAndSet) checkAndSet(s string, val string) {
 	if p.getValue() != s {
 		p.setValue(val)
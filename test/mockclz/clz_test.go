@@ -64,3 +64,17 @@ func TestClonedClzWithAutoFuncMock(t *testing.T) {
 	a.mock_cloneWithAutoMock_CallPeer_mockclz.AssertNumberOfCalls(t, "dummy", 1)
 	a.mock_cloneWithAutoMock_CallPeer_mockclz.AssertNumberOfCalls(t, "toJson", 1)
 }
+
+func TestClonedClzConstructor(t *testing.T) {
+	a := newCloneWithAutoMock(t, sourceClz{})
+
+	a.mock_cloneWithAutoMock_CallPeer_fmt.On("Printf", mock.Anything).Return(0, nil)
+	a.mock_cloneWithAutoMock_CallPeer_fmt.On("Sprintf", mock.Anything, mock.Anything).Return("")
+	a.mock_cloneWithAutoMock_CallPeer_mockclz.On("dummy").Return()
+	a.mock_cloneWithAutoMock_CallPeer_mockclz.On("toJson", mock.Anything).Return("")
+	a.On("Variadic", mock.Anything, mock.Anything).Return("Variadic is called")
+	a.On("Variadic4", mock.Anything, mock.Anything).Return("Variadic4 is called")
+
+	// expectations of the composite and its package mocks are asserted at cleanup
+	a.CallPeer()
+}
@@ -16,6 +16,20 @@ type cloneSourceClz struct {
 	mock.Mock
 }
 
+func newCloneSourceClz(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sourceClz) *cloneSourceClz {
+	m := &cloneSourceClz{sourceClz: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (sc *cloneSourceClz) Unnamed(s string, n int, c chan<- string) (string, error) {
 	if c == nil {
 		return "", errors.New("Invalid arguments")
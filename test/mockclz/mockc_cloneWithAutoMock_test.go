@@ -21,6 +21,24 @@ type mock_cloneWithAutoMock_CallPeer_fmt struct {
 	mock.Mock
 }
 
+func newCloneWithAutoMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sourceClz) *cloneWithAutoMock {
+	m := &cloneWithAutoMock{sourceClz: src}
+	m.Mock.Test(t)
+	m.mock_cloneWithAutoMock_CallPeer_mockclz.Test(t)
+	m.mock_cloneWithAutoMock_CallPeer_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_cloneWithAutoMock_CallPeer_mockclz.AssertExpectations(t)
+		m.mock_cloneWithAutoMock_CallPeer_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (sc *cloneWithAutoMock) CallPeer() {
 	dummy := sc.mock_cloneWithAutoMock_CallPeer_mockclz.dummy
 	fmt := &sc.mock_cloneWithAutoMock_CallPeer_fmt
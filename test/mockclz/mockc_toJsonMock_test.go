@@ -20,6 +20,24 @@ type mock_toJsonMock_toJson_json struct {
 	mock.Mock
 }
 
+func newToJsonMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *toJsonMock {
+	m := &toJsonMock{}
+	m.Mock.Test(t)
+	m.mock_toJsonMock_toJson_mockclz.Test(t)
+	m.mock_toJsonMock_toJson_json.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_toJsonMock_toJson_mockclz.AssertExpectations(t)
+		m.mock_toJsonMock_toJson_json.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *toJsonMock) toJson(o interface{}) string {
 	dummy := m.mock_toJsonMock_toJson_mockclz.dummy
 	json := &m.mock_toJsonMock_toJson_json
@@ -1,6 +1,8 @@
 package mockfn
 
 import (
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -31,3 +33,30 @@ func TestSampleClz(t *testing.T) {
 	sc3 := mockSampleClz3{}
 	assert.True(sc3.methodThatUsesMultileGlobalFunctions("format", "value") == "\"format\"mocked Sprintf")
 }
+
+type handlerFunc func() error
+
+func (f handlerFunc) Handle() error {
+	return f()
+}
+
+func TestMockFuncWithInterfaceLiterals(t *testing.T) {
+	assert := require.New(t)
+
+	m := &mockLibfnIntf{}
+	m.On("Register", mock.Anything).Return(
+		func(h interface{ Handle() error }) error {
+			return h.Handle()
+		},
+	)
+	m.On("Open", "data.txt").Return(io.NopCloser(strings.NewReader("content")), nil)
+
+	assert.NoError(m.Register(handlerFunc(func() error { return nil })))
+
+	rc, err := m.Open("data.txt")
+	assert.NoError(err)
+
+	b, _ := io.ReadAll(rc)
+	assert.Equal("content", string(b))
+	assert.NoError(rc.Close())
+}
@@ -0,0 +1,81 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package mockfn
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockLibfnIntf struct {
+	mock.Mock
+}
+
+func newMockLibfnIntf(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockLibfnIntf {
+	m := &mockLibfnIntf{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockLibfnIntf) Register(h interface{ Handle() error }) error {
+
+	_mc_ret := m.Called(h)
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func(interface{ Handle() error }) error); ok {
+		_r0 = _rfn(h)
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (m *mockLibfnIntf) Open(name string) (interface {
+	io.Reader
+	io.Closer
+}, error) {
+
+	_mc_ret := m.Called(name)
+
+	var _r0 interface {
+		io.Reader
+		io.Closer
+	}
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) interface {
+		io.Reader
+		io.Closer
+	}); ok {
+		_r0 = _rfn(name)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(interface {
+				io.Reader
+				io.Closer
+			})
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(name)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
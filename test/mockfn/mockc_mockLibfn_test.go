@@ -13,6 +13,20 @@ type mockLibfn struct {
 	mock.Mock
 }
 
+func newMockLibfn(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockLibfn {
+	m := &mockLibfn{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockLibfn) GetSecrets(projectId string, secretsRegexp *regexp.Regexp) ([]libfn.SecretData, error) {
 
 	_mc_ret := m.Called(projectId, secretsRegexp)
@@ -11,6 +11,20 @@ type mockSampleClz2 struct {
 	mock.Mock
 }
 
+func newMockSampleClz2(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sampleClz) *mockSampleClz2 {
+	m := &mockSampleClz2{sampleClz: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (c *mockSampleClz2) methodThatUsesMultileGlobalFunctions(format string, args ...interface{}) string {
 	fmt := fmtMock
 	json := jsonMock
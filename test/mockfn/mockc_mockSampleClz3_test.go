@@ -13,6 +13,20 @@ type mockSampleClz3 struct {
 	mock.Mock
 }
 
+func newMockSampleClz3(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sampleClz) *mockSampleClz3 {
+	m := &mockSampleClz3{sampleClz: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (c *mockSampleClz3) methodThatUsesMultileGlobalFunctions(format string, args ...interface{}) string {
 	fmt := fmtMock
 
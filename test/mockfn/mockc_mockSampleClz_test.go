@@ -11,6 +11,20 @@ type mockSampleClz struct {
 	mock.Mock
 }
 
+func newMockSampleClz(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sampleClz) *mockSampleClz {
+	m := &mockSampleClz{sampleClz: src}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (c *mockSampleClz) methodThatUsesGlobalFunction(format string, args ...interface{}) string {
 	fmt := fmtMock
 
@@ -4,4 +4,5 @@
 //go:generate mockcompose -n mockSampleClz2 -c sampleClz -real "methodThatUsesMultileGlobalFunctions,fmt=fmtMock:json=jsonMock"
 //go:generate mockcompose -n mockSampleClz3 -c sampleClz -real "methodThatUsesMultileGlobalFunctions,fmt=fmtMock"
 //go:generate mockcompose -n mockLibfn -p github.com/kelveny/mockcompose/test/libfn -mock GetSecrets
+//go:generate mockcompose -n mockLibfnIntf -p github.com/kelveny/mockcompose/test/libfn -mock Register -mock Open
 package mockfn
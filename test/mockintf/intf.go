@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/kelveny/mockcompose/test/foo"
+	"github.com/kelveny/mockcompose/test/libfn"
 )
 
 type SampleInterface interface {
@@ -93,3 +95,22 @@ func toJson(o interface{}) string {
 	}
 	return string(b)
 }
+
+type Getter interface {
+	Get(k string) ([]byte, error)
+	Keys() []string
+}
+
+// test embedded interfaces from other packages and overlapping methods
+type Store interface {
+	io.Closer
+	Getter
+
+	Get(k string) ([]byte, error)
+	Put(k string, v []byte) error
+}
+
+type SecretsStore interface {
+	libfn.SecretsInterface
+	io.Closer
+}
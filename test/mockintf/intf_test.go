@@ -1,9 +1,12 @@
 package mockintf
 
 import (
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/kelveny/mockcompose/test/foo"
+	"github.com/kelveny/mockcompose/test/libfn"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -182,3 +185,51 @@ func TestMockVoidReturn(t *testing.T) {
 	m.VoidReturn()
 	assert.True(m.AssertNumberOfCalls(t, "VoidReturn", 1))
 }
+
+type nameFilter string
+
+func (f nameFilter) Match(name string, tags ...string) bool {
+	return string(f) == name
+}
+
+func TestMockInterfaceWithInterfaceLiterals(t *testing.T) {
+	assert := require.New(t)
+
+	ch := make(chan string)
+	close(ch)
+
+	m := mockResourceInterface{}
+	m.On("Watch", nameFilter("config")).Return((<-chan string)(ch))
+
+	var r libfn.ResourceInterface = &m
+	assert.NotNil(r.Watch(nameFilter("config")))
+	m.AssertExpectations(t)
+}
+
+// generated mocks must implement interfaces with embedded interfaces
+var _ Store = (*mockStore)(nil)
+var _ SecretsStore = (*mockSecretsStore)(nil)
+var _ io.ReadWriteCloser = (*mockReadWriteCloser)(nil)
+
+func TestMockEmbeddedInterfaces(t *testing.T) {
+	assert := require.New(t)
+
+	s := &mockStore{}
+	s.On("Get", "key").Return([]byte("value"), nil)
+	s.On("Keys").Return([]string{"key"})
+	s.On("Close").Return(errors.New("closed"))
+
+	var store Store = s
+	v, err := store.Get("key")
+	assert.NoError(err)
+	assert.Equal("value", string(v))
+	assert.Equal([]string{"key"}, store.Keys())
+	assert.EqualError(store.Close(), "closed")
+
+	rwc := &mockReadWriteCloser{}
+	rwc.On("Write", []byte("data")).Return(4, nil)
+
+	n, err := rwc.Write([]byte("data"))
+	assert.NoError(err)
+	assert.Equal(4, n)
+}
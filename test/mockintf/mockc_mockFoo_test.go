@@ -10,6 +10,20 @@ type mockFoo struct {
 	mock.Mock
 }
 
+func newMockFoo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockFoo {
+	m := &mockFoo{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockFoo) Foo() string {
 
 	_mc_ret := m.Called()
@@ -0,0 +1,93 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package mockintf
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockReadWriteCloser struct {
+	mock.Mock
+}
+
+func newMockReadWriteCloser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockReadWriteCloser {
+	m := &mockReadWriteCloser{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockReadWriteCloser) Close() error {
+
+	_mc_ret := m.Called()
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func() error); ok {
+		_r0 = _rfn()
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (m *mockReadWriteCloser) Read(p []byte) (n int, err error) {
+
+	_mc_ret := m.Called(p)
+
+	var _r0 int
+
+	if _rfn, ok := _mc_ret.Get(0).(func([]byte) int); ok {
+		_r0 = _rfn(p)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(int)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func([]byte) error); ok {
+		_r1 = _rfn(p)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *mockReadWriteCloser) Write(p []byte) (n int, err error) {
+
+	_mc_ret := m.Called(p)
+
+	var _r0 int
+
+	if _rfn, ok := _mc_ret.Get(0).(func([]byte) int); ok {
+		_r0 = _rfn(p)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(int)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func([]byte) error); ok {
+		_r1 = _rfn(p)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
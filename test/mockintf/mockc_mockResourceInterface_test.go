@@ -0,0 +1,83 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package mockintf
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockResourceInterface struct {
+	mock.Mock
+}
+
+func newMockResourceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockResourceInterface {
+	m := &mockResourceInterface{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockResourceInterface) Open(name string) (interface {
+	io.Reader
+	io.Closer
+}, error) {
+
+	_mc_ret := m.Called(name)
+
+	var _r0 interface {
+		io.Reader
+		io.Closer
+	}
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) interface {
+		io.Reader
+		io.Closer
+	}); ok {
+		_r0 = _rfn(name)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(interface {
+				io.Reader
+				io.Closer
+			})
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(name)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *mockResourceInterface) Watch(filter interface{ Match(string, ...string) bool }) <-chan string {
+
+	_mc_ret := m.Called(filter)
+
+	var _r0 <-chan string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(interface{ Match(string, ...string) bool }) <-chan string); ok {
+		_r0 = _rfn(filter)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(<-chan string)
+		}
+	}
+
+	return _r0
+
+}
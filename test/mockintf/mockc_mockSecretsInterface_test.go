@@ -13,6 +13,20 @@ type mockSecretsInterface struct {
 	mock.Mock
 }
 
+func newMockSecretsInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockSecretsInterface {
+	m := &mockSecretsInterface{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockSecretsInterface) GetSecrets(projectId string, secretsRegexp *regexp.Regexp) ([]libfn.SecretData, error) {
 
 	_mc_ret := m.Called(projectId, secretsRegexp)
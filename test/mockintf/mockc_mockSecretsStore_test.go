@@ -0,0 +1,70 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package mockintf
+
+import (
+	"regexp"
+
+	"github.com/kelveny/mockcompose/test/libfn"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockSecretsStore struct {
+	mock.Mock
+}
+
+func newMockSecretsStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockSecretsStore {
+	m := &mockSecretsStore{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockSecretsStore) Close() error {
+
+	_mc_ret := m.Called()
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func() error); ok {
+		_r0 = _rfn()
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (m *mockSecretsStore) GetSecrets(projectId string, secretsRegexp *regexp.Regexp) ([]libfn.SecretData, error) {
+
+	_mc_ret := m.Called(projectId, secretsRegexp)
+
+	var _r0 []libfn.SecretData
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, *regexp.Regexp) []libfn.SecretData); ok {
+		_r0 = _rfn(projectId, secretsRegexp)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]libfn.SecretData)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string, *regexp.Regexp) error); ok {
+		_r1 = _rfn(projectId, secretsRegexp)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
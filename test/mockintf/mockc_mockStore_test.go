@@ -0,0 +1,101 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package mockintf
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStore struct {
+	mock.Mock
+}
+
+func newMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockStore {
+	m := &mockStore{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockStore) Get(k string) ([]byte, error) {
+
+	_mc_ret := m.Called(k)
+
+	var _r0 []byte
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) []byte); ok {
+		_r0 = _rfn(k)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]byte)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(k)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *mockStore) Put(k string, v []byte) error {
+
+	_mc_ret := m.Called(k, v)
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, []byte) error); ok {
+		_r0 = _rfn(k, v)
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (m *mockStore) Close() error {
+
+	_mc_ret := m.Called()
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func() error); ok {
+		_r0 = _rfn()
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (m *mockStore) Keys() []string {
+
+	_mc_ret := m.Called()
+
+	var _r0 []string
+
+	if _rfn, ok := _mc_ret.Get(0).(func() []string); ok {
+		_r0 = _rfn()
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]string)
+		}
+	}
+
+	return _r0
+
+}
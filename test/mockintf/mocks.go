@@ -1,4 +1,8 @@
 //go:generate mockcompose -n MockSampleInterface -i SampleInterface
 //go:generate mockcompose -n mockFoo -i Foo -p github.com/kelveny/mockcompose/test/foo
 //go:generate mockcompose -n mockSecretsInterface -i SecretsInterface -p github.com/kelveny/mockcompose/test/libfn
+//go:generate mockcompose -n mockResourceInterface -i ResourceInterface -p github.com/kelveny/mockcompose/test/libfn
+//go:generate mockcompose -n mockStore -i Store
+//go:generate mockcompose -n mockSecretsStore -i SecretsStore
+//go:generate mockcompose -n mockReadWriteCloser -i ReadWriteCloser -p io
 package mockintf
@@ -0,0 +1,121 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package multifile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type serviceMock struct {
+	Service
+	mock.Mock
+	mock_serviceMock_Describe_strconv
+}
+
+type mock_serviceMock_Describe_strconv struct {
+	mock.Mock
+}
+
+func newServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src Service) *serviceMock {
+	m := &serviceMock{Service: src}
+	m.Mock.Test(t)
+	m.mock_serviceMock_Describe_strconv.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_serviceMock_Describe_strconv.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (s *serviceMock) Process(input string) (string, error) {
+	if err := s.validate(input); err != nil {
+		return "", fmt.Errorf("invalid input %q: %w", input, err)
+	}
+	normalized := strings.ToLower(input)
+	if err := s.persist(normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
+func (m *serviceMock) validate(input string) error {
+
+	_mc_ret := m.Called(input)
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) error); ok {
+		_r0 = _rfn(input)
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (m *serviceMock) persist(value string) error {
+
+	_mc_ret := m.Called(value)
+
+	var _r0 error
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) error); ok {
+		_r0 = _rfn(value)
+	} else {
+		_r0 = _mc_ret.Error(0)
+	}
+
+	return _r0
+
+}
+
+func (s *serviceMock) Describe(count int) string {
+	strconv := &s.mock_serviceMock_Describe_strconv
+
+	return s.Name() + ":" + strconv.Itoa(count)
+}
+
+func (m *serviceMock) Name() string {
+
+	_mc_ret := m.Called()
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func() string); ok {
+		_r0 = _rfn()
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	return _r0
+
+}
+
+func (m *mock_serviceMock_Describe_strconv) Itoa(i int) string {
+
+	_mc_ret := m.Called(i)
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(int) string); ok {
+		_r0 = _rfn(i)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	return _r0
+
+}
@@ -0,0 +1,2 @@
+//go:generate mockcompose -n serviceMock -c Service -real Process,this -real Describe,this:strconv
+package multifile
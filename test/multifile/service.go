@@ -0,0 +1,27 @@
+package multifile
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Service struct {
+	name string
+}
+
+func (s *Service) Process(input string) (string, error) {
+	if err := s.validate(input); err != nil {
+		return "", fmt.Errorf("invalid input %q: %w", input, err)
+	}
+
+	normalized := strings.ToLower(input)
+	if err := s.persist(normalized); err != nil {
+		return "", err
+	}
+
+	return normalized, nil
+}
+
+func (s *Service) Name() string {
+	return s.name
+}
@@ -0,0 +1,21 @@
+package multifile
+
+import (
+	"errors"
+	"strconv"
+)
+
+func (s *Service) Describe(count int) string {
+	return s.Name() + ":" + strconv.Itoa(count)
+}
+
+func (s *Service) validate(input string) error {
+	if input == "" {
+		return errors.New("empty")
+	}
+	return nil
+}
+
+func (s *Service) persist(value string) error {
+	return nil
+}
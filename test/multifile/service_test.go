@@ -0,0 +1,44 @@
+package multifile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessWithPeersFromOtherFile(t *testing.T) {
+	assert := require.New(t)
+
+	s := &serviceMock{}
+	s.On("validate", "Input").Return(nil)
+	s.On("persist", "input").Return(nil)
+
+	v, err := s.Process("Input")
+	assert.NoError(err)
+	assert.Equal("input", v)
+
+	s.AssertExpectations(t)
+}
+
+func TestProcessValidationFailure(t *testing.T) {
+	assert := require.New(t)
+
+	s := &serviceMock{}
+	s.On("validate", "bad").Return(errors.New("rejected"))
+
+	_, err := s.Process("bad")
+	assert.EqualError(err, `invalid input "bad": rejected`)
+
+	s.AssertNotCalled(t, "persist", "bad")
+}
+
+func TestDescribeWithPeerFromOtherFile(t *testing.T) {
+	assert := require.New(t)
+
+	s := &serviceMock{}
+	s.On("Name").Return("svc")
+	s.mock_serviceMock_Describe_strconv.On("Itoa", 3).Return("three")
+
+	assert.Equal("svc:three", s.Describe(3))
+}
@@ -0,0 +1,6 @@
+package v1
+
+type Deployment struct {
+	Name     string
+	Replicas int
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"github.com/kelveny/mockcompose/test/multiver"
+	"github.com/kelveny/mockcompose/test/multiver/apps/v1"
+)
+
+type rollout struct {
+	deployer multiver.Deployer
+}
+
+func (r *rollout) Run(d *v1.Deployment) (int, error) {
+	pods, err := r.deployer.Deploy(d)
+	if err != nil {
+		return 0, err
+	}
+
+	return multiver.Scale(d, len(pods)) + len(multiver.PodsOf(d)), nil
+}
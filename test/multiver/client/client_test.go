@@ -0,0 +1,32 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "github.com/kelveny/mockcompose/test/multiver/apps/v1"
+	corev1 "github.com/kelveny/mockcompose/test/multiver/core/v1"
+)
+
+func TestRolloutWithSameNamedPackages(t *testing.T) {
+	assert := require.New(t)
+
+	deployer := &mockDeployer{}
+	deployer.On("Deploy", mock.Anything).Return([]*corev1.Pod{{Name: "p1"}, {Name: "p2"}}, nil)
+
+	r := &rolloutMock{
+		rollout: rollout{deployer: deployer},
+	}
+	r.mock_rolloutMock_Run_multiver.On("Scale", mock.Anything, 2).Return(2)
+	r.mock_rolloutMock_Run_multiver.On("PodsOf", mock.Anything).Return([]*corev1.Pod{{Name: "p1"}})
+
+	n, err := r.Run(&appsv1.Deployment{Name: "app"})
+	assert.NoError(err)
+	assert.Equal(3, n)
+
+	fns := &mockMultiver{}
+	fns.On("PodsOf", mock.Anything).Return(nil)
+	assert.Nil(fns.PodsOf(&appsv1.Deployment{}))
+}
@@ -0,0 +1,53 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package client
+
+import (
+	appsv1 "github.com/kelveny/mockcompose/test/multiver/apps/v1"
+	corev1 "github.com/kelveny/mockcompose/test/multiver/core/v1"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDeployer struct {
+	mock.Mock
+}
+
+func newMockDeployer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockDeployer {
+	m := &mockDeployer{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockDeployer) Deploy(d *appsv1.Deployment) ([]*corev1.Pod, error) {
+
+	_mc_ret := m.Called(d)
+
+	var _r0 []*corev1.Pod
+
+	if _rfn, ok := _mc_ret.Get(0).(func(*appsv1.Deployment) []*corev1.Pod); ok {
+		_r0 = _rfn(d)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]*corev1.Pod)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(*appsv1.Deployment) error); ok {
+		_r1 = _rfn(d)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
@@ -0,0 +1,63 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package client
+
+import (
+	appsv1 "github.com/kelveny/mockcompose/test/multiver/apps/v1"
+	corev1 "github.com/kelveny/mockcompose/test/multiver/core/v1"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockMultiver struct {
+	mock.Mock
+}
+
+func newMockMultiver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockMultiver {
+	m := &mockMultiver{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockMultiver) PodsOf(d *appsv1.Deployment) []*corev1.Pod {
+
+	_mc_ret := m.Called(d)
+
+	var _r0 []*corev1.Pod
+
+	if _rfn, ok := _mc_ret.Get(0).(func(*appsv1.Deployment) []*corev1.Pod); ok {
+		_r0 = _rfn(d)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]*corev1.Pod)
+		}
+	}
+
+	return _r0
+
+}
+
+func (m *mockMultiver) Scale(d *appsv1.Deployment, replicas int) int {
+
+	_mc_ret := m.Called(d, replicas)
+
+	var _r0 int
+
+	if _rfn, ok := _mc_ret.Get(0).(func(*appsv1.Deployment, int) int); ok {
+		_r0 = _rfn(d, replicas)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(int)
+		}
+	}
+
+	return _r0
+
+}
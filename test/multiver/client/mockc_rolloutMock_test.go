@@ -0,0 +1,81 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package client
+
+import (
+	v1 "github.com/kelveny/mockcompose/test/multiver/apps/v1"
+	corev1 "github.com/kelveny/mockcompose/test/multiver/core/v1"
+	"github.com/stretchr/testify/mock"
+)
+
+type rolloutMock struct {
+	rollout
+	mock.Mock
+	mock_rolloutMock_Run_multiver
+}
+
+type mock_rolloutMock_Run_multiver struct {
+	mock.Mock
+}
+
+func newRolloutMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src rollout) *rolloutMock {
+	m := &rolloutMock{rollout: src}
+	m.Mock.Test(t)
+	m.mock_rolloutMock_Run_multiver.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_rolloutMock_Run_multiver.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (r *rolloutMock) Run(d *v1.Deployment) (int, error) {
+	multiver := &r.mock_rolloutMock_Run_multiver
+
+	pods, err := r.deployer.Deploy(d)
+	if err != nil {
+		return 0, err
+	}
+	return multiver.Scale(d, len(pods)) + len(multiver.PodsOf(d)), nil
+}
+
+func (m *mock_rolloutMock_Run_multiver) Scale(d *v1.Deployment, replicas int) int {
+
+	_mc_ret := m.Called(d, replicas)
+
+	var _r0 int
+
+	if _rfn, ok := _mc_ret.Get(0).(func(*v1.Deployment, int) int); ok {
+		_r0 = _rfn(d, replicas)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(int)
+		}
+	}
+
+	return _r0
+
+}
+
+func (m *mock_rolloutMock_Run_multiver) PodsOf(d *v1.Deployment) []*corev1.Pod {
+
+	_mc_ret := m.Called(d)
+
+	var _r0 []*corev1.Pod
+
+	if _rfn, ok := _mc_ret.Get(0).(func(*v1.Deployment) []*corev1.Pod); ok {
+		_r0 = _rfn(d)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]*corev1.Pod)
+		}
+	}
+
+	return _r0
+
+}
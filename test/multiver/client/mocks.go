@@ -0,0 +1,4 @@
+//go:generate mockcompose -n mockDeployer -i Deployer -p github.com/kelveny/mockcompose/test/multiver
+//go:generate mockcompose -n mockMultiver -p github.com/kelveny/mockcompose/test/multiver -mock PodsOf -mock Scale
+//go:generate mockcompose -n rolloutMock -c rollout -real Run,multiver
+package client
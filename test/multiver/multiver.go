@@ -0,0 +1,18 @@
+package multiver
+
+import (
+	appsv1 "github.com/kelveny/mockcompose/test/multiver/apps/v1"
+	corev1 "github.com/kelveny/mockcompose/test/multiver/core/v1"
+)
+
+type Deployer interface {
+	Deploy(d *appsv1.Deployment) ([]*corev1.Pod, error)
+}
+
+func PodsOf(d *appsv1.Deployment) []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0, d.Replicas)
+	for i := 0; i < d.Replicas; i++ {
+		pods = append(pods, &corev1.Pod{Name: d.Name})
+	}
+	return pods
+}
@@ -0,0 +1,12 @@
+package multiver
+
+import (
+	"github.com/kelveny/mockcompose/test/multiver/apps/v1"
+)
+
+// Scale uses unaliased import of apps/v1, while core/v1 is referenced only
+// through PodsOf, which is declared in another file with different aliases
+func Scale(d *v1.Deployment, replicas int) int {
+	d.Replicas = replicas
+	return len(PodsOf(d))
+}
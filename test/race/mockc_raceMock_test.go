@@ -14,6 +14,20 @@ type raceMock struct {
 	mock.Mock
 }
 
+func newRaceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *raceMock {
+	m := &raceMock{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *raceMock) WorkRun(wg *sync.WaitGroup) {
 
 	m.Called(wg)
@@ -15,6 +15,20 @@ type raceMock struct {
 	mock.Mock
 }
 
+func newRaceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *raceMock {
+	m := &raceMock{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *raceMock) WorkRun(ctx context.Context) {
 
 	m.Called(ctx)
@@ -0,0 +1,254 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package spy
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type expectingStore struct {
+	mock.Mock
+	delegate    Store
+	spyLock     sync.Mutex
+	spyExpected sync.Map
+}
+
+func newExpectingStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, delegate Store) *expectingStore {
+	m := &expectingStore{delegate: delegate}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+// On sets up an expectation of method on the spy, once a method has expectations
+// its calls are matched against them, like calls of a mock, instead of being
+// delegated to the real implementation
+func (m *expectingStore) On(methodName string, arguments ...interface{}) *mock.Call {
+	m.spyExpected.Store(methodName, true)
+	return m.Mock.On(methodName, arguments...)
+}
+
+// spy calls method with args through the mock if the method has expectations,
+// otherwise the call is only recorded and delegated is true, caller then
+// delegates the call to the real implementation. Calls are matched and recorded
+// one at a time, Run functions of expectations must not wait for other calls
+// of the spy or assert on it
+func (m *expectingStore) spy(method string, args ...interface{}) (ret mock.Arguments, delegated bool) {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+
+	if _, ok := m.spyExpected.Load(method); ok || m.delegate == nil {
+		return m.MethodCalled(method, args...), false
+	}
+
+	m.Calls = append(m.Calls, mock.Call{Parent: &m.Mock, Method: method, Arguments: args})
+	return nil, true
+}
+
+// assertions of the spy wait for in-flight calls that may record delegated calls
+
+func (m *expectingStore) AssertExpectations(t mock.TestingT) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertExpectations(t)
+}
+
+func (m *expectingStore) AssertNumberOfCalls(t mock.TestingT, methodName string, expectedCalls int) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNumberOfCalls(t, methodName, expectedCalls)
+}
+
+func (m *expectingStore) AssertCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertCalled(t, methodName, arguments...)
+}
+
+func (m *expectingStore) AssertNotCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNotCalled(t, methodName, arguments...)
+}
+
+type expectingStore_Expecter struct {
+	mock *expectingStore
+}
+
+func (m *expectingStore) EXPECT() *expectingStore_Expecter {
+	return &expectingStore_Expecter{mock: m}
+}
+func (m *expectingStore) Get(key string) (string, error) {
+
+	_mc_ret, _mc_delegated := m.spy("Get", key)
+	if _mc_delegated {
+		return m.delegate.Get(key)
+	}
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) (string, error)); ok {
+		return _rfn(key)
+	}
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) string); ok {
+		_r0 = _rfn(key)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(key)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+type expectingStore_Get_Call struct {
+	*mock.Call
+}
+
+func (_e *expectingStore_Expecter) Get(key interface{}) *expectingStore_Get_Call {
+	return &expectingStore_Get_Call{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *expectingStore_Get_Call) Run(run func(key string)) *expectingStore_Get_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		run(_a0)
+	})
+	return _c
+}
+
+func (_c *expectingStore_Get_Call) Return(_r0 string, _r1 error) *expectingStore_Get_Call {
+	_c.Call.Return(_r0, _r1)
+	return _c
+}
+
+func (_c *expectingStore_Get_Call) RunAndReturn(run func(string) (string, error)) *expectingStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (m *expectingStore) Put(key string, value string) {
+
+	if _, _mc_delegated := m.spy("Put", key, value); _mc_delegated {
+		m.delegate.Put(key, value)
+	}
+
+}
+
+type expectingStore_Put_Call struct {
+	*mock.Call
+}
+
+func (_e *expectingStore_Expecter) Put(key interface{}, value interface{}) *expectingStore_Put_Call {
+	return &expectingStore_Put_Call{Call: _e.mock.On("Put", key, value)}
+}
+
+func (_c *expectingStore_Put_Call) Run(run func(key string, value string)) *expectingStore_Put_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		var _a1 string
+		if _args[1] != nil {
+			_a1 = _args[1].(string)
+		}
+		run(_a0, _a1)
+	})
+	return _c
+}
+
+func (_c *expectingStore_Put_Call) Return() *expectingStore_Put_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *expectingStore_Put_Call) RunAndReturn(run func(string, string)) *expectingStore_Put_Call {
+	return _c.Run(run)
+}
+
+func (m *expectingStore) Keys(prefix string, tags ...string) []string {
+
+	_mc_args := make([]interface{}, 0, 1+len(tags))
+
+	_mc_args = append(_mc_args, prefix)
+
+	for _, _va := range tags {
+		_mc_args = append(_mc_args, _va)
+	}
+
+	_mc_ret, _mc_delegated := m.spy("Keys", _mc_args...)
+	if _mc_delegated {
+		return m.delegate.Keys(prefix, tags...)
+	}
+
+	var _r0 []string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, ...string) []string); ok {
+		_r0 = _rfn(prefix, tags...)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]string)
+		}
+	}
+
+	return _r0
+
+}
+
+type expectingStore_Keys_Call struct {
+	*mock.Call
+}
+
+func (_e *expectingStore_Expecter) Keys(prefix interface{}, tags ...interface{}) *expectingStore_Keys_Call {
+	return &expectingStore_Keys_Call{Call: _e.mock.On("Keys", append([]interface{}{prefix}, tags...)...)}
+}
+
+func (_c *expectingStore_Keys_Call) Run(run func(prefix string, tags ...string)) *expectingStore_Keys_Call {
+	_c.Call.Run(func(_args mock.Arguments) {
+		var _a0 string
+		if _args[0] != nil {
+			_a0 = _args[0].(string)
+		}
+		_a1 := make([]string, len(_args)-1)
+		for _i, _a := range _args[1:] {
+			if _a != nil {
+				_a1[_i] = _a.(string)
+			}
+		}
+		run(_a0, _a1...)
+	})
+	return _c
+}
+
+func (_c *expectingStore_Keys_Call) Return(_r0 []string) *expectingStore_Keys_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+func (_c *expectingStore_Keys_Call) RunAndReturn(run func(string, ...string) []string) *expectingStore_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,102 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package spy
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPair[K comparable, V any] struct {
+	mock.Mock
+	delegate    Pair[K, V]
+	spyLock     sync.Mutex
+	spyExpected sync.Map
+}
+
+func newMockPair[K comparable, V any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}, delegate Pair[K, V]) *mockPair[K, V] {
+	m := &mockPair[K, V]{delegate: delegate}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+// On sets up an expectation of method on the spy, once a method has expectations
+// its calls are matched against them, like calls of a mock, instead of being
+// delegated to the real implementation
+func (m *mockPair[K, V]) On(methodName string, arguments ...interface{}) *mock.Call {
+	m.spyExpected.Store(methodName, true)
+	return m.Mock.On(methodName, arguments...)
+}
+
+// spy calls method with args through the mock if the method has expectations,
+// otherwise the call is only recorded and delegated is true, caller then
+// delegates the call to the real implementation. Calls are matched and recorded
+// one at a time, Run functions of expectations must not wait for other calls
+// of the spy or assert on it
+func (m *mockPair[K, V]) spy(method string, args ...interface{}) (ret mock.Arguments, delegated bool) {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+
+	if _, ok := m.spyExpected.Load(method); ok || m.delegate == nil {
+		return m.MethodCalled(method, args...), false
+	}
+
+	m.Calls = append(m.Calls, mock.Call{Parent: &m.Mock, Method: method, Arguments: args})
+	return nil, true
+}
+
+// assertions of the spy wait for in-flight calls that may record delegated calls
+
+func (m *mockPair[K, V]) AssertExpectations(t mock.TestingT) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertExpectations(t)
+}
+
+func (m *mockPair[K, V]) AssertNumberOfCalls(t mock.TestingT, methodName string, expectedCalls int) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNumberOfCalls(t, methodName, expectedCalls)
+}
+
+func (m *mockPair[K, V]) AssertCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertCalled(t, methodName, arguments...)
+}
+
+func (m *mockPair[K, V]) AssertNotCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNotCalled(t, methodName, arguments...)
+}
+
+func (m *mockPair[K, V]) Set(k K, v V) bool {
+
+	_mc_ret, _mc_delegated := m.spy("Set", k, v)
+	if _mc_delegated {
+		return m.delegate.Set(k, v)
+	}
+
+	var _r0 bool
+
+	if _rfn, ok := _mc_ret.Get(0).(func(K, V) bool); ok {
+		_r0 = _rfn(k, v)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(bool)
+		}
+	}
+
+	return _r0
+
+}
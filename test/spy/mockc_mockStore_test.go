@@ -0,0 +1,147 @@
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package spy
+
+import (
+	"sync"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStore struct {
+	mock.Mock
+	delegate    Store
+	spyLock     sync.Mutex
+	spyExpected sync.Map
+}
+
+func newMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, delegate Store) *mockStore {
+	m := &mockStore{delegate: delegate}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+// On sets up an expectation of method on the spy, once a method has expectations
+// its calls are matched against them, like calls of a mock, instead of being
+// delegated to the real implementation
+func (m *mockStore) On(methodName string, arguments ...interface{}) *mock.Call {
+	m.spyExpected.Store(methodName, true)
+	return m.Mock.On(methodName, arguments...)
+}
+
+// spy calls method with args through the mock if the method has expectations,
+// otherwise the call is only recorded and delegated is true, caller then
+// delegates the call to the real implementation. Calls are matched and recorded
+// one at a time, Run functions of expectations must not wait for other calls
+// of the spy or assert on it
+func (m *mockStore) spy(method string, args ...interface{}) (ret mock.Arguments, delegated bool) {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+
+	if _, ok := m.spyExpected.Load(method); ok || m.delegate == nil {
+		return m.MethodCalled(method, args...), false
+	}
+
+	m.Calls = append(m.Calls, mock.Call{Parent: &m.Mock, Method: method, Arguments: args})
+	return nil, true
+}
+
+// assertions of the spy wait for in-flight calls that may record delegated calls
+
+func (m *mockStore) AssertExpectations(t mock.TestingT) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertExpectations(t)
+}
+
+func (m *mockStore) AssertNumberOfCalls(t mock.TestingT, methodName string, expectedCalls int) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNumberOfCalls(t, methodName, expectedCalls)
+}
+
+func (m *mockStore) AssertCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertCalled(t, methodName, arguments...)
+}
+
+func (m *mockStore) AssertNotCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool {
+	m.spyLock.Lock()
+	defer m.spyLock.Unlock()
+	return m.Mock.AssertNotCalled(t, methodName, arguments...)
+}
+
+func (m *mockStore) Get(key string) (string, error) {
+
+	_mc_ret, _mc_delegated := m.spy("Get", key)
+	if _mc_delegated {
+		return m.delegate.Get(key)
+	}
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) string); ok {
+		_r0 = _rfn(key)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(key)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *mockStore) Put(key string, value string) {
+
+	if _, _mc_delegated := m.spy("Put", key, value); _mc_delegated {
+		m.delegate.Put(key, value)
+	}
+
+}
+
+func (m *mockStore) Keys(prefix string, tags ...string) []string {
+
+	_mc_args := make([]interface{}, 0, 1+len(tags))
+
+	_mc_args = append(_mc_args, prefix)
+
+	for _, _va := range tags {
+		_mc_args = append(_mc_args, _va)
+	}
+
+	_mc_ret, _mc_delegated := m.spy("Keys", _mc_args...)
+	if _mc_delegated {
+		return m.delegate.Keys(prefix, tags...)
+	}
+
+	var _r0 []string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, ...string) []string); ok {
+		_r0 = _rfn(prefix, tags...)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).([]string)
+		}
+	}
+
+	return _r0
+
+}
@@ -0,0 +1,6 @@
+//go:generate mockcompose -n mockStore -i Store -spy
+//go:generate mockcompose -n expectingStore -i Store -spy -expecter
+//go:generate mockcompose -n mockPair -i Pair -spy
+//go:generate mockcompose -n fakeStore -i Store -spy -backend fake
+//go:generate mockcompose -n mockReader -i Reader -p io -spy
+package spy
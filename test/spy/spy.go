@@ -0,0 +1,47 @@
+package spy
+
+import (
+	"errors"
+	"strings"
+)
+
+type Store interface {
+	Get(key string) (string, error)
+	Put(key string, value string)
+	Keys(prefix string, tags ...string) []string
+}
+
+type Pair[K comparable, V any] interface {
+	Set(k K, v V) bool
+}
+
+type mapStore map[string]string
+
+func (s mapStore) Get(key string) (string, error) {
+	if v, ok := s[key]; ok {
+		return v, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (s mapStore) Put(key string, value string) {
+	s[key] = value
+}
+
+func (s mapStore) Keys(prefix string, tags ...string) []string {
+	keys := []string{}
+	for k := range s {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	return append(keys, tags...)
+}
+
+type mapPair[K comparable, V any] map[K]V
+
+func (p mapPair[K, V]) Set(k K, v V) bool {
+	_, ok := p[k]
+	p[k] = v
+	return !ok
+}
@@ -0,0 +1,194 @@
+package spy
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpyDelegates(t *testing.T) {
+	assert := require.New(t)
+
+	s := newMockStore(t, mapStore{"name": "value"})
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value", v)
+
+	_, err = s.Get("missing")
+	assert.EqualError(err, "not found")
+
+	s.Put("other", "value")
+	assert.Equal([]string{"other", "t1"}, s.Keys("o", "t1"))
+
+	s.AssertCalled(t, "Get", "name")
+	s.AssertCalled(t, "Get", "missing")
+	s.AssertCalled(t, "Put", "other", "value")
+	s.AssertCalled(t, "Keys", "o", "t1")
+	s.AssertNumberOfCalls(t, "Get", 2)
+}
+
+func TestSpyOverrides(t *testing.T) {
+	assert := require.New(t)
+
+	s := newMockStore(t, mapStore{"name": "value"})
+	s.On("Get", "name").Return("mocked", nil).Once()
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("mocked", v)
+
+	// methods without expectations are still delegated
+	s.Put("other", "value")
+	assert.Equal([]string{"other"}, s.Keys("o"))
+
+	s.AssertNumberOfCalls(t, "Get", 1)
+	s.AssertCalled(t, "Put", "other", "value")
+	s.AssertCalled(t, "Keys", "o")
+}
+
+func TestSpyExpectationsNotAdded(t *testing.T) {
+	assert := require.New(t)
+
+	s := newMockStore(t, mapStore{"name": "value"})
+	for i := 0; i < 3; i++ {
+		_, err := s.Get("name")
+		assert.NoError(err)
+	}
+
+	assert.Empty(s.ExpectedCalls)
+	s.AssertNumberOfCalls(t, "Get", 3)
+}
+
+func TestSpyExpecter(t *testing.T) {
+	assert := require.New(t)
+
+	s := newExpectingStore(t, mapStore{"name": "value"})
+	s.EXPECT().Get("name").Return("mocked", nil)
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("mocked", v)
+
+	s.Put("other", "value")
+	assert.Equal([]string{"other"}, s.Keys("o"))
+}
+
+func TestSpyConcurrentExpectations(t *testing.T) {
+	assert := require.New(t)
+
+	s := newMockStore(t, mapStore{"name": "value"})
+
+	values := make(chan string, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _ := s.Get("name")
+			values <- v
+		}()
+	}
+	s.On("Get", "name").Return("mocked", nil).Maybe()
+	s.On("Put", "name", mock.Anything).Return().Maybe()
+	wg.Wait()
+	close(values)
+
+	for v := range values {
+		assert.Contains([]string{"value", "mocked"}, v)
+	}
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("mocked", v)
+	s.AssertNumberOfCalls(t, "Get", 11)
+}
+
+func TestSpyConcurrentCalls(t *testing.T) {
+	assert := require.New(t)
+
+	s := newMockStore(t, mapStore{"name": "value"})
+	s.On("Keys", "k").Return([]string{"mocked"}).Times(10)
+
+	values := make(chan string, 10)
+	keys := make(chan []string, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			v, _ := s.Get("name")
+			values <- v
+		}()
+		go func() {
+			defer wg.Done()
+			keys <- s.Keys("k")
+		}()
+	}
+	wg.Wait()
+	close(values)
+	close(keys)
+
+	for v := range values {
+		assert.Equal("value", v)
+	}
+	for k := range keys {
+		assert.Equal([]string{"mocked"}, k)
+	}
+
+	s.AssertNumberOfCalls(t, "Get", 10)
+	s.AssertNumberOfCalls(t, "Keys", 10)
+}
+
+func TestSpyGenericInterface(t *testing.T) {
+	assert := require.New(t)
+
+	p := newMockPair[string, int](t, mapPair[string, int]{})
+	assert.True(p.Set("k", 1))
+	assert.False(p.Set("k", 2))
+
+	p.On("Set", "k", mock.Anything).Return(true)
+	assert.True(p.Set("k", 3))
+
+	p.AssertCalled(t, "Set", "k", 2)
+}
+
+func TestSpyPackageInterface(t *testing.T) {
+	assert := require.New(t)
+
+	r := newMockReader(t, strings.NewReader("abc"))
+	b, err := io.ReadAll(r)
+	assert.NoError(err)
+	assert.Equal("abc", string(b))
+
+	r.On("Read", mock.Anything).Return(0, errors.New("broken"))
+	_, err = r.Read(make([]byte, 1))
+	assert.EqualError(err, "broken")
+}
+
+func TestFakeSpy(t *testing.T) {
+	assert := require.New(t)
+
+	s := newFakeStore(mapStore{"name": "value"})
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value", v)
+
+	s.GetFunc = func(key string) (string, error) { return "mocked", nil }
+	v, _ = s.Get("name")
+	assert.Equal("mocked", v)
+
+	s.Put("other", "value")
+	assert.Equal([]string{"other"}, s.Keys("o"))
+
+	assert.Equal([]fakeStoreGetCall{{Key: "name"}, {Key: "name"}}, s.GetCalls())
+	assert.Equal([]fakeStorePutCall{{Key: "other", Value: "value"}}, s.PutCalls())
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The mockcompose Authors. All rights reserved.
+
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package template
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockGreeter struct {
+	Greeter
+	mock.Mock
+	mock_mockGreeter_Greet_fmt
+}
+
+type mock_mockGreeter_Greet_fmt struct {
+	mock.Mock
+}
+
+func newMockGreeter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src Greeter) *mockGreeter {
+	m := &mockGreeter{Greeter: src}
+	m.Mock.Test(t)
+	m.mock_mockGreeter_Greet_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockGreeter_Greet_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockGreeter) known(name string) bool {
+
+	_mc_ret := m.Called(name)
+
+	var _r0 bool
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) bool); ok {
+		_r0 = _rfn(name)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(bool)
+		}
+	}
+
+	return _r0
+
+}
+
+func (m *mock_mockGreeter_Greet_fmt) Sprintf(format string, a ...interface{}) string {
+
+	_mc_args := make([]interface{}, 0, 1+len(a))
+
+	_mc_args = append(_mc_args, format)
+
+	for _, _va := range a {
+		_mc_args = append(_mc_args, _va)
+	}
+
+	_mc_ret := m.Called(_mc_args...)
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string, ...interface{}) string); ok {
+		_r0 = _rfn(format, a...)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	return _r0
+
+}
+
+func (g *mockGreeter) Greet(name string) string {
+	fmt := &g.mock_mockGreeter_Greet_fmt
+
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, name)
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+// mockGreeterMethods lists methods mocked by mockGreeter
+var mockGreeterMethods = []string{
+	"mockGreeter.known(name string) bool",
+	"mock_mockGreeter_Greet_fmt.Sprintf(format string, a ...interface{}) string",
+}
+
+// mockGreeterClones lists number of methods cloned into mockGreeter
+const mockGreeterClones = 1
@@ -0,0 +1,68 @@
+// Copyright 2024 The mockcompose Authors. All rights reserved.
+
+// CODE GENERATED AUTOMATICALLY WITH github.com/kelveny/mockcompose
+// THIS FILE SHOULD NOT BE EDITED BY HAND
+package template
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStore struct {
+	mock.Mock
+}
+
+func newMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockStore {
+	m := &mockStore{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
+func (m *mockStore) Get(key string) (string, error) {
+
+	_mc_ret := m.Called(key)
+
+	var _r0 string
+
+	if _rfn, ok := _mc_ret.Get(0).(func(string) string); ok {
+		_r0 = _rfn(key)
+	} else {
+		if _mc_ret.Get(0) != nil {
+			_r0 = _mc_ret.Get(0).(string)
+		}
+	}
+
+	var _r1 error
+
+	if _rfn, ok := _mc_ret.Get(1).(func(string) error); ok {
+		_r1 = _rfn(key)
+	} else {
+		_r1 = _mc_ret.Error(1)
+	}
+
+	return _r0, _r1
+
+}
+
+func (m *mockStore) Put(key string, value string) {
+
+	m.Called(key, value)
+
+}
+
+// mockStoreMethods lists methods mocked by mockStore
+var mockStoreMethods = []string{
+	"mockStore.Get(key string) (string, error)",
+	"mockStore.Put(key string, value string) ",
+}
+
+// mockStoreClones lists number of methods cloned into mockStore
+const mockStoreClones = 0
@@ -0,0 +1,3 @@
+//go:generate mockcompose -n mockStore -i Store -template license.tmpl
+//go:generate mockcompose -n mockGreeter -c Greeter -real Greet,this:fmt -template license.tmpl
+package template
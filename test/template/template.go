@@ -0,0 +1,23 @@
+package template
+
+import "fmt"
+
+type Store interface {
+	Get(key string) (string, error)
+	Put(key string, value string)
+}
+
+type Greeter struct {
+	greeting string
+}
+
+func (g *Greeter) Greet(name string) string {
+	if g.known(name) {
+		return fmt.Sprintf("%s again, %s", g.greeting, name)
+	}
+	return fmt.Sprintf("%s, %s", g.greeting, name)
+}
+
+func (g *Greeter) known(name string) bool {
+	return false
+}
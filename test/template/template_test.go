@@ -0,0 +1,37 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateInterface(t *testing.T) {
+	assert := require.New(t)
+
+	s := newMockStore(t)
+	s.On("Get", "name").Return("value", nil)
+
+	v, err := s.Get("name")
+	assert.NoError(err)
+	assert.Equal("value", v)
+
+	assert.Equal([]string{
+		"mockStore.Get(key string) (string, error)",
+		"mockStore.Put(key string, value string) ",
+	}, mockStoreMethods)
+	assert.Equal(0, mockStoreClones)
+}
+
+func TestTemplateClassComposite(t *testing.T) {
+	assert := require.New(t)
+
+	g := newMockGreeter(t, Greeter{greeting: "hello"})
+	g.On("known", "bob").Return(true)
+	g.mock_mockGreeter_Greet_fmt.On("Sprintf", "%s again, %s", mock.Anything, mock.Anything).Return("mocked")
+
+	assert.Equal("mocked", g.Greet("bob"))
+	assert.Len(mockGreeterMethods, 2)
+	assert.Equal(1, mockGreeterClones)
+}
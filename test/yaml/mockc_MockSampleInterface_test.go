@@ -11,6 +11,20 @@ type MockSampleInterface struct {
 	mock.Mock
 }
 
+func NewMockSampleInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSampleInterface {
+	m := &MockSampleInterface{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *MockSampleInterface) Unnamed(_a0 string, _a1 int, _a2 chan<- string) error {
 
 	_mc_ret := m.Called(_a0, _a1, _a2)
@@ -32,6 +32,28 @@ type mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions2_fmt struct
 	mock.Mock
 }
 
+func newMockFmtclonedFuncs(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockFmtclonedFuncs {
+	m := &mockFmtclonedFuncs{}
+	m.Mock.Test(t)
+	m.mock_mockFmtclonedFuncs_functionThatUsesGlobalFunction_fmt.Test(t)
+	m.mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions_fmt.Test(t)
+	m.mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions_json.Test(t)
+	m.mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions2_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockFmtclonedFuncs_functionThatUsesGlobalFunction_fmt.AssertExpectations(t)
+		m.mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions_fmt.AssertExpectations(t)
+		m.mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions_json.AssertExpectations(t)
+		m.mock_mockFmtclonedFuncs_functionThatUsesMultileGlobalFunctions2_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockFmtclonedFuncs) functionThatUsesGlobalFunction(format string, args ...interface{}) string {
 	fmt := &m.mock_mockFmtclonedFuncs_functionThatUsesGlobalFunction_fmt
 
@@ -10,6 +10,20 @@ type mockJson struct {
 	mock.Mock
 }
 
+func newMockJson(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockJson {
+	m := &mockJson{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (m *mockJson) Marshal(v interface{}) ([]byte, error) {
 
 	_mc_ret := m.Called(v)
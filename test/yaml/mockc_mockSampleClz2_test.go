@@ -21,6 +21,24 @@ type mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_json struct {
 	mock.Mock
 }
 
+func newMockSampleClz2(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sampleClz) *mockSampleClz2 {
+	m := &mockSampleClz2{sampleClz: src}
+	m.Mock.Test(t)
+	m.mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_fmt.Test(t)
+	m.mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_json.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_fmt.AssertExpectations(t)
+		m.mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_json.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (c *mockSampleClz2) methodThatUsesMultileGlobalFunctions(format string, args ...interface{}) string {
 	fmt := &c.mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_fmt
 	json := &c.mock_mockSampleClz2_methodThatUsesMultileGlobalFunctions_json
@@ -18,6 +18,22 @@ type mock_mockSampleClz3_methodThatUsesMultileGlobalFunctions_fmt struct {
 	mock.Mock
 }
 
+func newMockSampleClz3(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sampleClz) *mockSampleClz3 {
+	m := &mockSampleClz3{sampleClz: src}
+	m.Mock.Test(t)
+	m.mock_mockSampleClz3_methodThatUsesMultileGlobalFunctions_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockSampleClz3_methodThatUsesMultileGlobalFunctions_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (c *mockSampleClz3) methodThatUsesMultileGlobalFunctions(format string, args ...interface{}) string {
 	fmt := &c.mock_mockSampleClz3_methodThatUsesMultileGlobalFunctions_fmt
 
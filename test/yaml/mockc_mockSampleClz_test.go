@@ -16,6 +16,22 @@ type mock_mockSampleClz_methodThatUsesGlobalFunction_fmt struct {
 	mock.Mock
 }
 
+func newMockSampleClz(t interface {
+	mock.TestingT
+	Cleanup(func())
+}, src sampleClz) *mockSampleClz {
+	m := &mockSampleClz{sampleClz: src}
+	m.Mock.Test(t)
+	m.mock_mockSampleClz_methodThatUsesGlobalFunction_fmt.Test(t)
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+		m.mock_mockSampleClz_methodThatUsesGlobalFunction_fmt.AssertExpectations(t)
+	})
+
+	return m
+}
+
 func (c *mockSampleClz) methodThatUsesGlobalFunction(format string, args ...interface{}) string {
 	fmt := &c.mock_mockSampleClz_methodThatUsesGlobalFunction_fmt
 